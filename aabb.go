@@ -0,0 +1,148 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents an axis-aligned bounding box.
+type AABB struct {
+	//	Minimum and maximum corners.
+	Min, Max Vec3
+}
+
+//	Returns a new `*AABB` tightly enclosing all specified `points`, or an empty `*AABB` (see `Reset`) if none are specified.
+func NewAABB(points ...Vec3) (me *AABB) {
+	me = new(AABB)
+	me.Reset()
+	for i := range points {
+		me.AddPoint(&points[i])
+	}
+	return
+}
+
+//	Returns a new `*AABB` centered at `center` with the specified `halfExtents`.
+func NewAABBCenter(center, halfExtents *Vec3) (me *AABB) {
+	me = new(AABB)
+	me.Min.SetFromSub(center, halfExtents)
+	me.Max.SetFromAdd(center, halfExtents)
+	return
+}
+
+//	Grows `me` as needed to enclose `aabb`.
+func (me *AABB) Add(aabb *AABB) {
+	me.Min.Set(math.Min(me.Min.X, aabb.Min.X), math.Min(me.Min.Y, aabb.Min.Y), math.Min(me.Min.Z, aabb.Min.Z))
+	me.Max.Set(math.Max(me.Max.X, aabb.Max.X), math.Max(me.Max.Y, aabb.Max.Y), math.Max(me.Max.Z, aabb.Max.Z))
+}
+
+//	Grows `me` as needed to enclose `point`.
+func (me *AABB) AddPoint(point *Vec3) {
+	me.Min.Set(math.Min(me.Min.X, point.X), math.Min(me.Min.Y, point.Y), math.Min(me.Min.Z, point.Z))
+	me.Max.Set(math.Max(me.Max.X, point.X), math.Max(me.Max.Y, point.Y), math.Max(me.Max.Z, point.Z))
+}
+
+//	Returns the center of `me`.
+func (me *AABB) Center() *Vec3 {
+	return &Vec3{0.5 * (me.Min.X + me.Max.X), 0.5 * (me.Min.Y + me.Max.Y), 0.5 * (me.Min.Z + me.Max.Z)}
+}
+
+//	Returns the point in (or on) `me` that is closest to `point`.
+func (me *AABB) ClosestPoint(point *Vec3) (cp *Vec3) {
+	cp = &Vec3{point.X, point.Y, point.Z}
+	cp.Clamp(&me.Min, &me.Max)
+	return
+}
+
+//	Returns whether `aabb` lies entirely within (or on the boundary of) `me`.
+func (me *AABB) Contains(aabb *AABB) bool {
+	return aabb.Min.AllGEq(&me.Min) && aabb.Max.AllLEq(&me.Max)
+}
+
+//	Returns whether `point` lies within (or on the boundary of) `me`.
+func (me *AABB) ContainsPoint(point *Vec3) bool {
+	return point.AllGEq(&me.Min) && point.AllLEq(&me.Max)
+}
+
+//	Returns whether `me` encloses nothing, ie. any `Min` component exceeds its `Max` counterpart.
+func (me *AABB) Empty() bool {
+	return me.Min.X > me.Max.X || me.Min.Y > me.Max.Y || me.Min.Z > me.Max.Z
+}
+
+//	Returns the half-size of `me` along each axis.
+func (me *AABB) HalfExtents() *Vec3 {
+	return me.Max.SubScaled(&me.Min, 0.5)
+}
+
+//	Returns whether `me` and `aabb` overlap (or touch).
+func (me *AABB) Intersects(aabb *AABB) bool {
+	return me.Min.AllLEq(&aabb.Max) && aabb.Min.AllLEq(&me.Max)
+}
+
+//	Returns whether `me` and the sphere at `center` with `radius` overlap (or touch).
+func (me *AABB) IntersectsSphere(center *Vec3, radius float64) bool {
+	return me.SqDistance(center) <= radius*radius
+}
+
+//	Sets `me` to the empty state, from which the first `Add` or `AddPoint` call makes it enclose exactly its argument.
+func (me *AABB) Reset() {
+	me.Min.SetToMax()
+	me.Max.SetToMin()
+}
+
+//	Returns the size of `me` along each axis.
+func (me *AABB) Size() *Vec3 {
+	return me.Max.Sub(&me.Min)
+}
+
+//	Returns the squared distance from `point` to `me`, or 0 if `point` lies within `me`.
+func (me *AABB) SqDistance(point *Vec3) float64 {
+	return me.ClosestPoint(point).Sub(point).Length()
+}
+
+//	Returns the corner of `me` that is farthest along `dir`.
+func (me *AABB) Support(dir Vec3) (p Vec3) {
+	p = me.Min
+	if dir.X > 0 {
+		p.X = me.Max.X
+	}
+	if dir.Y > 0 {
+		p.Y = me.Max.Y
+	}
+	if dir.Z > 0 {
+		p.Z = me.Max.Z
+	}
+	return
+}
+
+//	Returns the total surface area of `me`, or 0 if `me` is `Empty`.
+func (me *AABB) SurfaceArea() float64 {
+	if me.Empty() {
+		return 0
+	}
+	d := me.Size()
+	return 2 * (d.X*d.Y + d.Y*d.Z + d.Z*d.X)
+}
+
+//	Sets `me` to the `AABB` enclosing `me` after transformation by the affine `mat`.
+func (me *AABB) Transform(mat *Mat4) {
+	if me.Empty() {
+		return
+	}
+	c, h := me.Center(), me.HalfExtents()
+	c.TransformCoord(mat)
+	h.Set(
+		math.Abs(mat[0])*h.X+math.Abs(mat[4])*h.Y+math.Abs(mat[8])*h.Z,
+		math.Abs(mat[1])*h.X+math.Abs(mat[5])*h.Y+math.Abs(mat[9])*h.Z,
+		math.Abs(mat[2])*h.X+math.Abs(mat[6])*h.Y+math.Abs(mat[10])*h.Z,
+	)
+	me.Min.SetFromSub(c, h)
+	me.Max.SetFromAdd(c, h)
+}
+
+//	Returns the volume of `me`, or 0 if `me` is `Empty`.
+func (me *AABB) Volume() float64 {
+	if me.Empty() {
+		return 0
+	}
+	d := me.Size()
+	return d.X * d.Y * d.Z
+}
@@ -0,0 +1,394 @@
+package unum
+
+import (
+	"math"
+	"sort"
+)
+
+//	The number of candidate split planes per axis evaluated by the surface area heuristic in `NewBVH`.
+var BVHBins = 16
+
+//	Tests the item with the specified `id` against `ray` for `BVH.RayCast` and `BVH.RayCastAny`,
+//	returning the ray parameter `t` (not exceeding `maxT`) of the hit, if any.
+type BVHRayFunc func(id int, ray *Ray, maxT float64) (t float64, ok bool)
+
+//	Represents a bounding volume hierarchy over arbitrary items, each bounded by an `AABB` and identified by an `int` ID.
+//
+//	Built top-down with the surface area heuristic by `NewBVH`, it also supports incremental `Insert` and `Remove`,
+//	plus `Refit` or `Move` after items moved. A `BVH` is not safe for concurrent modification.
+type BVH struct {
+	nodes []bvhNode
+	root  int
+
+	leaves    []int
+	items     []interface{}
+	freeNodes []int
+	freeIDs   []int
+}
+
+type bvhNode struct {
+	bounds              AABB
+	parent, left, right int
+
+	//	for leaves (with `left` of -1), the item ID
+	id int
+}
+
+//	Returns a new `*BVH` over the items with the specified `bounds` and (optional, else `nil`) associated `items`.
+//	The item IDs are the indices into `bounds`.
+func NewBVH(bounds []AABB, items []interface{}) (me *BVH) {
+	me = &BVH{root: -1, leaves: make([]int, len(bounds)), items: make([]interface{}, len(bounds))}
+	copy(me.items, items)
+	ids := make([]int, len(bounds))
+	centers := make([]Vec3, len(bounds))
+	for i := range bounds {
+		ids[i], centers[i] = i, *bounds[i].Center()
+	}
+	if len(ids) > 0 {
+		me.nodes = make([]bvhNode, 0, 2*len(ids)-1)
+		me.root = me.build(bounds, centers, ids, -1)
+	}
+	return
+}
+
+func (me *BVH) build(bounds []AABB, centers []Vec3, ids []int, parent int) (n int) {
+	n = me.newNode(parent)
+	node := &me.nodes[n]
+	if len(ids) == 1 {
+		node.bounds, node.id = bounds[ids[0]], ids[0]
+		me.leaves[ids[0]] = n
+		return
+	}
+	var cb AABB
+	cb.Reset()
+	for _, id := range ids {
+		cb.AddPoint(&centers[id])
+	}
+	//	binned SAH: find the cheapest split plane over all axes
+	bestCost, bestAxis, bestSplit := math.Inf(1), -1, 0.0
+	bins := BVHBins
+	if bins < 2 {
+		bins = 2
+	}
+	counts, boxes, rightArea := make([]int, bins), make([]AABB, bins), make([]float64, bins)
+	for axis := 0; axis < 3; axis++ {
+		lo, hi := cb.Min.at(axis), cb.Max.at(axis)
+		if hi <= lo {
+			continue
+		}
+		scale := float64(bins) / (hi - lo)
+		for b := range boxes {
+			counts[b] = 0
+			boxes[b].Reset()
+		}
+		for _, id := range ids {
+			b := int((centers[id].at(axis) - lo) * scale)
+			if b >= bins {
+				b = bins - 1
+			}
+			counts[b]++
+			boxes[b].Add(&bounds[id])
+		}
+		var acc AABB
+		acc.Reset()
+		for b := bins - 1; b > 0; b-- {
+			acc.Add(&boxes[b])
+			rightArea[b] = acc.SurfaceArea()
+		}
+		acc.Reset()
+		nl, total := 0, len(ids)
+		for b := 0; b < bins-1; b++ {
+			acc.Add(&boxes[b])
+			if nl += counts[b]; nl == 0 || nl == total {
+				continue
+			}
+			if cost := acc.SurfaceArea()*float64(nl) + rightArea[b+1]*float64(total-nl); cost < bestCost {
+				bestCost, bestAxis, bestSplit = cost, axis, lo+float64(b+1)/scale
+			}
+		}
+	}
+	mid := 0
+	if bestAxis >= 0 {
+		for i := range ids {
+			if centers[ids[i]].at(bestAxis) < bestSplit {
+				ids[i], ids[mid] = ids[mid], ids[i]
+				mid++
+			}
+		}
+	}
+	if mid == 0 || mid == len(ids) {
+		//	all centers coincide (or fell into one bin): split at the median of the widest axis
+		axis := 0
+		if size := cb.Size(); size.Y > size.X && size.Y >= size.Z {
+			axis = 1
+		} else if size.Z > size.X && size.Z > size.Y {
+			axis = 2
+		}
+		sort.Slice(ids, func(i, j int) bool { return centers[ids[i]].at(axis) < centers[ids[j]].at(axis) })
+		mid = len(ids) / 2
+	}
+	left := me.build(bounds, centers, ids[:mid], n)
+	right := me.build(bounds, centers, ids[mid:], n)
+	node = &me.nodes[n]
+	node.left, node.right = left, right
+	node.bounds = me.nodes[left].bounds
+	node.bounds.Add(&me.nodes[right].bounds)
+	return
+}
+
+func (me *BVH) newNode(parent int) (n int) {
+	if l := len(me.freeNodes); l > 0 {
+		n, me.freeNodes = me.freeNodes[l-1], me.freeNodes[:l-1]
+		me.nodes[n] = bvhNode{parent: parent, left: -1, right: -1, id: -1}
+	} else {
+		n = len(me.nodes)
+		me.nodes = append(me.nodes, bvhNode{parent: parent, left: -1, right: -1, id: -1})
+	}
+	return
+}
+
+//	Returns the bounds of the item with the specified `id`.
+func (me *BVH) Bounds(id int) *AABB {
+	return &me.nodes[me.leaves[id]].bounds
+}
+
+//	Returns the item associated with the specified `id`.
+func (me *BVH) Item(id int) interface{} {
+	return me.items[id]
+}
+
+//	Adds an item with the specified `bounds` and associated `item` (which may be `nil`) and returns its ID.
+//
+//	The new leaf is paired with the existing node whose enclosing bounds would grow least in surface area.
+func (me *BVH) Insert(bounds *AABB, item interface{}) (id int) {
+	if l := len(me.freeIDs); l > 0 {
+		id, me.freeIDs = me.freeIDs[l-1], me.freeIDs[:l-1]
+		me.items[id] = item
+	} else {
+		id = len(me.leaves)
+		me.leaves, me.items = append(me.leaves, -1), append(me.items, item)
+	}
+	leaf := me.newNode(-1)
+	me.nodes[leaf].bounds, me.nodes[leaf].id = *bounds, id
+	me.leaves[id] = leaf
+	me.insertLeaf(leaf)
+	return
+}
+
+func (me *BVH) insertLeaf(leaf int) {
+	if me.root < 0 {
+		me.root = leaf
+		return
+	}
+	bounds := me.nodes[leaf].bounds
+	sibling := me.root
+	var merged AABB
+	for me.nodes[sibling].left >= 0 {
+		node := &me.nodes[sibling]
+		merged = node.bounds
+		merged.Add(&bounds)
+		area := node.bounds.SurfaceArea()
+		//	cost of pairing with this node versus the (lower-bound) cost of descending into either child
+		cost, inherit := 2*merged.SurfaceArea(), 2*(merged.SurfaceArea()-area)
+		childCost := func(c int) float64 {
+			merged = me.nodes[c].bounds
+			merged.Add(&bounds)
+			if me.nodes[c].left < 0 {
+				return merged.SurfaceArea() + inherit
+			}
+			return merged.SurfaceArea() - me.nodes[c].bounds.SurfaceArea() + inherit
+		}
+		costLeft, costRight := childCost(node.left), childCost(node.right)
+		if cost < costLeft && cost < costRight {
+			break
+		}
+		if costLeft < costRight {
+			sibling = node.left
+		} else {
+			sibling = node.right
+		}
+	}
+	oldParent := me.nodes[sibling].parent
+	parent := me.newNode(oldParent)
+	me.nodes[parent].left, me.nodes[parent].right = sibling, leaf
+	me.nodes[sibling].parent, me.nodes[leaf].parent = parent, parent
+	if oldParent < 0 {
+		me.root = parent
+	} else if me.nodes[oldParent].left == sibling {
+		me.nodes[oldParent].left = parent
+	} else {
+		me.nodes[oldParent].right = parent
+	}
+	me.refitUp(parent)
+}
+
+//	Updates the bounds of the item with the specified `id` and of all its ancestors.
+//	For items that moved far, consider `Remove` and `Insert` to maintain query efficiency.
+func (me *BVH) Move(id int, bounds *AABB) {
+	leaf := me.leaves[id]
+	me.nodes[leaf].bounds = *bounds
+	me.refitUp(me.nodes[leaf].parent)
+}
+
+//	Recomputes the bounds of all inner nodes after any number of `SetBounds` calls.
+func (me *BVH) Refit() {
+	if me.root >= 0 {
+		me.refit(me.root)
+	}
+}
+
+func (me *BVH) refit(n int) {
+	if node := &me.nodes[n]; node.left >= 0 {
+		me.refit(node.left)
+		me.refit(node.right)
+		node.bounds = me.nodes[node.left].bounds
+		node.bounds.Add(&me.nodes[node.right].bounds)
+	}
+}
+
+func (me *BVH) refitUp(n int) {
+	for ; n >= 0; n = me.nodes[n].parent {
+		node := &me.nodes[n]
+		node.bounds = me.nodes[node.left].bounds
+		node.bounds.Add(&me.nodes[node.right].bounds)
+	}
+}
+
+//	Removes the item with the specified `id`, which may then be reused by `Insert`.
+func (me *BVH) Remove(id int) {
+	leaf := me.leaves[id]
+	me.leaves[id], me.items[id] = -1, nil
+	me.freeIDs = append(me.freeIDs, id)
+	me.freeNodes = append(me.freeNodes, leaf)
+	parent := me.nodes[leaf].parent
+	if parent < 0 {
+		me.root = -1
+		return
+	}
+	sibling := me.nodes[parent].left
+	if sibling == leaf {
+		sibling = me.nodes[parent].right
+	}
+	grand := me.nodes[parent].parent
+	me.nodes[sibling].parent = grand
+	me.freeNodes = append(me.freeNodes, parent)
+	if grand < 0 {
+		me.root = sibling
+		return
+	}
+	if me.nodes[grand].left == parent {
+		me.nodes[grand].left = sibling
+	} else {
+		me.nodes[grand].right = sibling
+	}
+	me.refitUp(grand)
+}
+
+//	Sets the bounds of the item with the specified `id` without updating its ancestors, which `Refit` then does
+//	for all items at once.
+func (me *BVH) SetBounds(id int, bounds *AABB) {
+	me.nodes[me.leaves[id]].bounds = *bounds
+}
+
+//	Calls `visit` with the ID of every item whose bounds pass `test`, until `visit` returns `false`.
+func (me *BVH) query(test func(*AABB) bool, visit func(id int) bool) {
+	if me.root < 0 {
+		return
+	}
+	stack := []int{me.root}
+	for len(stack) > 0 {
+		node := &me.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !test(&node.bounds) {
+			continue
+		}
+		if node.left < 0 {
+			if !visit(node.id) {
+				return
+			}
+		} else {
+			stack = append(stack, node.right, node.left)
+		}
+	}
+}
+
+//	Calls `visit` with the ID of every item whose bounds overlap `aabb`, until `visit` returns `false`.
+func (me *BVH) QueryAABB(aabb *AABB, visit func(id int) bool) {
+	me.query(aabb.Intersects, visit)
+}
+
+//	Calls `visit` with the ID of every item whose bounds overlap `frustum`, until `visit` returns `false`.
+func (me *BVH) QueryFrustum(frustum *Frustum, visit func(id int) bool) {
+	me.query(frustum.IntersectsAABB, visit)
+}
+
+//	Calls `visit` with the ID of every item whose bounds overlap the sphere at `center` with `radius`,
+//	until `visit` returns `false`.
+func (me *BVH) QuerySphere(center *Vec3, radius float64, visit func(id int) bool) {
+	me.query(func(b *AABB) bool { return b.IntersectsSphere(center, radius) }, visit)
+}
+
+//	Returns the nearest item hit by `ray` up to parameter `maxT`, and the ray parameter `t` of the hit.
+//	Items are tested with `hit`, or if `nil`, by their bounds alone. Nodes are visited front to back,
+//	skipping all that lie beyond the nearest hit found so far.
+func (me *BVH) RayCast(ray *Ray, maxT float64, hit BVHRayFunc) (id int, t float64, ok bool) {
+	return me.rayCast(ray, maxT, hit, false)
+}
+
+//	Returns any item hit by `ray` up to parameter `maxT`, and the ray parameter `t` of the hit, stopping at the first
+//	found. Items are tested with `hit`, or if `nil`, by their bounds alone. Suitable for occlusion and line-of-sight tests.
+func (me *BVH) RayCastAny(ray *Ray, maxT float64, hit BVHRayFunc) (id int, t float64, ok bool) {
+	return me.rayCast(ray, maxT, hit, true)
+}
+
+func (me *BVH) rayCast(ray *Ray, maxT float64, hit BVHRayFunc, first bool) (id int, t float64, ok bool) {
+	id, t = -1, maxT
+	if me.root < 0 {
+		return
+	}
+	type entry struct {
+		n    int
+		tMin float64
+	}
+	tMin, _, in := ray.IntersectAABB(&me.nodes[me.root].bounds)
+	if !in || tMin > t {
+		return
+	}
+	stack := []entry{{me.root, tMin}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if e.tMin > t {
+			continue
+		}
+		node := &me.nodes[e.n]
+		if node.left < 0 {
+			th, hitOk := e.tMin, true
+			if hit != nil {
+				th, hitOk = hit(node.id, ray, t)
+			}
+			if hitOk && th <= t {
+				id, t, ok = node.id, th, true
+				if first {
+					return
+				}
+			}
+			continue
+		}
+		t0, _, in0 := ray.IntersectAABB(&me.nodes[node.left].bounds)
+		t1, _, in1 := ray.IntersectAABB(&me.nodes[node.right].bounds)
+		in0, in1 = in0 && t0 <= t, in1 && t1 <= t
+		//	push the farther child first, so that the nearer one is visited next
+		if in0 && in1 && t0 < t1 {
+			stack = append(stack, entry{node.right, t1}, entry{node.left, t0})
+		} else {
+			if in0 {
+				stack = append(stack, entry{node.left, t0})
+			}
+			if in1 {
+				stack = append(stack, entry{node.right, t1})
+			}
+		}
+	}
+	return
+}
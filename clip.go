@@ -0,0 +1,366 @@
+package unum
+
+import (
+	"math"
+	"sort"
+)
+
+//	Boolean operations supported by `PolygonBoolean`.
+type PolygonBoolOp int
+
+const (
+	//	Points in either operand.
+	PolygonUnion PolygonBoolOp = iota
+
+	//	Points in both operands.
+	PolygonIntersection
+
+	//	Points in the first but not the second operand.
+	PolygonDifference
+
+	//	Points in exactly one of both operands.
+	PolygonXor
+)
+
+//	Join styles for the corners produced by `PolygonOffset`.
+type PolygonJoin int
+
+const (
+	//	Extends both adjacent edges until they meet, falling back to a bevel beyond the miter limit.
+	PolygonJoinMiter PolygonJoin = iota
+
+	//	Connects both adjacent edges with a circular arc.
+	PolygonJoinRound
+)
+
+//	The maximum deviation of the arcs produced by `PolygonJoinRound` from the true circle, relative to the offset distance.
+var PolygonArcTolerance = 0.01
+
+//	Clips `subject` against the convex polygon `window` (of either winding) using the Sutherland-Hodgman algorithm,
+//	and returns the clipped polygon, which is empty if `subject` lies entirely outside `window`.
+//
+//	If `subject` is concave, the result may contain degenerate zero-area bridges between its disjoint parts.
+func PolygonClipConvex(subject, window Polygon) (clipped Polygon) {
+	sign := 1.0
+	if !window.IsCCW() {
+		sign = -1
+	}
+	clipped = append(clipped, subject...)
+	for i := range window {
+		a, b := &window[i], &window[(i+1)%len(window)]
+		in := clipped
+		if clipped = nil; len(in) == 0 {
+			break
+		}
+		prev := &in[len(in)-1]
+		prevIn := sign*Orient2D(a, b, prev) >= 0
+		for j := range in {
+			cur := &in[j]
+			curIn := sign*Orient2D(a, b, cur) >= 0
+			if curIn != prevIn {
+				if p, _, _, ok := lineIntersect(prev, cur, a, b); ok {
+					clipped = append(clipped, *p)
+				}
+			}
+			if curIn {
+				clipped = append(clipped, *cur)
+			}
+			prev, prevIn = cur, curIn
+		}
+	}
+	return
+}
+
+//	Intersects the infinite lines through `a0`, `a1` and through `b0`, `b1`, returning the intersection `point`
+//	plus its parameters `t` along `a` and `u` along `b`. Parallel lines don't intersect.
+func lineIntersect(a0, a1, b0, b1 *Vec2) (point *Vec2, t, u float64, ok bool) {
+	r, s, d := a1.Sub(a0), b1.Sub(b0), b0.Sub(a0)
+	denom := r.Cross(s)
+	if ok = denom != 0; ok {
+		t, u = d.Cross(s)/denom, d.Cross(r)/denom
+		point = &Vec2{a0.X + r.X*t, a0.Y + r.Y*t}
+	}
+	return
+}
+
+//	Computes the Boolean operation `op` on the regions `a` and `b`, each given as a set of simple polygons
+//	(outer boundaries and holes, of any winding) whose interiors are determined by the even-odd rule.
+//
+//	Returns the boundary polygons of the resulting region: outer boundaries counter-clockwise, holes clockwise.
+//	All edges are split at their mutual intersections; each resulting edge piece is kept if the result region lies
+//	on exactly one of its sides, then all kept pieces are linked into closed polygons.
+func PolygonBoolean(op PolygonBoolOp, a, b []Polygon) []Polygon {
+	ops := [2][]Polygon{a, b}
+	return polygonBoolean(append(append([]Polygon{}, a...), b...), func(p *Vec2) bool {
+		inA, inB := polygonsContain(ops[0], p, true), polygonsContain(ops[1], p, true)
+		switch op {
+		case PolygonIntersection:
+			return inA && inB
+		case PolygonDifference:
+			return inA && !inB
+		case PolygonXor:
+			return inA != inB
+		}
+		return inA || inB
+	})
+}
+
+//	Returns whether `p` lies within the region described by `rings`, according to the even-odd rule if `evenOdd`,
+//	else according to the positive winding rule.
+func polygonsContain(rings []Polygon, p *Vec2, evenOdd bool) bool {
+	wn := 0
+	for _, ring := range rings {
+		wn += ring.WindingNumber(p)
+	}
+	if evenOdd {
+		return wn%2 != 0
+	}
+	return wn > 0
+}
+
+type polyEdge struct {
+	a, b Vec2
+}
+
+//	Appends to `ts` the parameters along `me` of those `points` lying strictly between its endpoints.
+func (me *polyEdge) splitAt(ts []float64, points ...*Vec2) []float64 {
+	d := me.b.Sub(&me.a)
+	if dd := d.Length(); dd > 0 {
+		for _, p := range points {
+			if t := p.Sub(&me.a).Dot(d) / dd; t > 0 && t < 1 {
+				ts = append(ts, t)
+			}
+		}
+	}
+	return ts
+}
+
+func polygonBoolean(rings []Polygon, inside func(*Vec2) bool) (result []Polygon) {
+	var edges []polyEdge
+	for _, ring := range rings {
+		for i := range ring {
+			if j := (i + 1) % len(ring); ring[i] != ring[j] {
+				edges = append(edges, polyEdge{ring[i], ring[j]})
+			}
+		}
+	}
+	if len(edges) == 0 {
+		return
+	}
+	min, max := rings[0].Bounds()
+	for _, ring := range rings[1:] {
+		lo, hi := ring.Bounds()
+		min, max = Vec2_Min(min, lo), Vec2_Max(max, hi)
+	}
+	scale := math.Max(max.X-min.X, max.Y-min.Y)
+	snap := polySnapper{q: scale * 1E-10, pts: map[[2]int64]Vec2{}}
+	offset := scale * 1E-07
+	//	split all edges at their mutual intersections
+	splits := make([][]float64, len(edges))
+	for i := range edges {
+		ei := &edges[i]
+		for j := i + 1; j < len(edges); j++ {
+			ej := &edges[j]
+			_, t, u, ok := SegmentIntersect(&ei.a, &ei.b, &ej.a, &ej.b)
+			if !ok {
+				continue
+			}
+			if r, s := ei.b.Sub(&ei.a), ej.b.Sub(&ej.a); math.Abs(r.Cross(s)) <= Epsilon*r.Magnitude()*s.Magnitude() {
+				//	collinear overlap: split each edge at the other's endpoints
+				splits[i] = ei.splitAt(splits[i], &ej.a, &ej.b)
+				splits[j] = ej.splitAt(splits[j], &ei.a, &ei.b)
+				continue
+			}
+			if t > 0 && t < 1 {
+				splits[i] = append(splits[i], t)
+			}
+			if u > 0 && u < 1 {
+				splits[j] = append(splits[j], u)
+			}
+		}
+	}
+	//	classify all edge pieces, keeping those separating the result region from its complement, oriented to have it on their left
+	kept := map[polyEdge]bool{}
+	var pieces []polyEdge
+	for i := range edges {
+		e := &edges[i]
+		ts := append(splits[i], 0, 1)
+		sort.Float64s(ts)
+		d := e.b.Sub(&e.a)
+		prev := snap.at(&e.a)
+		for _, t := range ts[1:] {
+			cur := snap.at(&Vec2{e.a.X + d.X*t, e.a.Y + d.Y*t})
+			if t == 1 {
+				cur = snap.at(&e.b)
+			}
+			if cur == prev {
+				continue
+			}
+			mid, n := Vec2{0.5 * (prev.X + cur.X), 0.5 * (prev.Y + cur.Y)}, cur.Sub(&prev).Perp()
+			n.NormalizeSafe()
+			left, right := inside(&Vec2{mid.X + n.X*offset, mid.Y + n.Y*offset}), inside(&Vec2{mid.X - n.X*offset, mid.Y - n.Y*offset})
+			if left != right {
+				pe := polyEdge{prev, cur}
+				if right {
+					pe = polyEdge{cur, prev}
+				}
+				if !kept[pe] {
+					kept[pe] = true
+					pieces = append(pieces, pe)
+				}
+			}
+			prev = cur
+		}
+	}
+	return polyLink(pieces)
+}
+
+//	Merges points closer than the quantum `q` into the first such point encountered.
+type polySnapper struct {
+	q   float64
+	pts map[[2]int64]Vec2
+}
+
+func (me *polySnapper) at(p *Vec2) Vec2 {
+	if me.q == 0 {
+		return *p
+	}
+	kx, ky := int64(math.Floor(p.X/me.q+0.5)), int64(math.Floor(p.Y/me.q+0.5))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			if s, ok := me.pts[[2]int64{kx + dx, ky + dy}]; ok && math.Abs(s.X-p.X) <= me.q && math.Abs(s.Y-p.Y) <= me.q {
+				return s
+			}
+		}
+	}
+	me.pts[[2]int64{kx, ky}] = *p
+	return *p
+}
+
+//	Links directed edges into closed polygons, taking the leftmost turn wherever several edges leave the same vertex,
+//	and drops collinear vertices.
+func polyLink(pieces []polyEdge) (result []Polygon) {
+	out := map[Vec2][]int{}
+	for i, e := range pieces {
+		out[e.a] = append(out[e.a], i)
+	}
+	used := make([]bool, len(pieces))
+	for start := range pieces {
+		if used[start] {
+			continue
+		}
+		var ring Polygon
+		for cur := start; cur >= 0 && !used[cur]; {
+			used[cur] = true
+			e := &pieces[cur]
+			ring = append(ring, e.a)
+			din, next, best := e.b.Sub(&e.a), -1, math.Inf(-1)
+			for _, k := range out[e.b] {
+				if !used[k] || k == start {
+					dout := pieces[k].b.Sub(&pieces[k].a)
+					if turn := math.Atan2(din.Cross(dout), din.Dot(dout)); turn > best {
+						next, best = k, turn
+					}
+				}
+			}
+			cur = next
+		}
+		if ring = ring.withoutCollinear(); len(ring) >= 3 {
+			result = append(result, ring)
+		}
+	}
+	return
+}
+
+//	Returns a copy of `me` without vertices lying on the straight line between their neighbors.
+func (me Polygon) withoutCollinear() (p Polygon) {
+	for i := range me {
+		prev, next := &me[(i+len(me)-1)%len(me)], &me[(i+1)%len(me)]
+		d0, d1 := me[i].Sub(prev), next.Sub(&me[i])
+		if math.Abs(d0.Cross(d1)) > Epsilon*d0.Magnitude()*d1.Magnitude() || d0.Dot(d1) < 0 {
+			p = append(p, me[i])
+		}
+	}
+	return
+}
+
+//	Inflates (for positive `delta`) or deflates (for negative `delta`) the region described by `rings`
+//	(simple polygons with interiors determined by the even-odd rule) by the distance `|delta|`.
+//
+//	Corners where the offset edges separate are joined according to `join`; for `PolygonJoinMiter`, miters
+//	longer than `miterLimit * |delta|` are beveled (a `miterLimit` below 1 is treated as 1).
+//	Returns the boundary polygons of the resulting region: outer boundaries counter-clockwise, holes clockwise.
+func PolygonOffset(rings []Polygon, delta float64, join PolygonJoin, miterLimit float64) []Polygon {
+	miterLimit = math.Max(miterLimit, 1)
+	var raw []Polygon
+	for i, ring := range rings {
+		if len(ring) < 3 {
+			continue
+		}
+		//	orient outer boundaries counter-clockwise and holes clockwise, so that the region is left of all edges
+		depth := 0
+		for j, other := range rings {
+			if j != i && other.ContainsPoint(&ring[0]) {
+				depth++
+			}
+		}
+		ring = append(Polygon{}, ring...).withoutCollinear()
+		if ring.IsCCW() != (depth%2 == 0) {
+			ring.Reverse()
+		}
+		if len(ring) >= 3 {
+			raw = append(raw, ring.offset(delta, join, miterLimit))
+		}
+	}
+	return polygonBoolean(raw, func(p *Vec2) bool {
+		return polygonsContain(raw, p, false)
+	})
+}
+
+//	Offsets all edges of `me` by `delta` to their right, and joins them at the corners.
+func (me Polygon) offset(delta float64, join PolygonJoin, miterLimit float64) (raw Polygon) {
+	n := len(me)
+	normal := func(i int) *Vec2 {
+		d := me[(i+1)%n].Sub(&me[i])
+		d.NormalizeSafe()
+		return &Vec2{d.Y, -d.X}
+	}
+	step := 2 * math.Acos(1-math.Min(PolygonArcTolerance, 1))
+	for i := range me {
+		n0, n1 := normal((i+n-1)%n), normal(i)
+		v := &me[i]
+		p0, p1 := Vec2{v.X + n0.X*delta, v.Y + n0.Y*delta}, Vec2{v.X + n1.X*delta, v.Y + n1.Y*delta}
+		if sin := n0.Cross(n1); sin*delta <= 0 {
+			//	the offset edges overlap: detour via the vertex, `polygonBoolean` then discards the resulting inner loop
+			raw = append(raw, p0, *v, p1)
+			continue
+		}
+		switch cos := n0.Dot(n1); join {
+		case PolygonJoinRound:
+			a0 := math.Atan2(n0.Y, n0.X)
+			sweep := math.Atan2(n1.Y, n1.X) - a0
+			if sweep > math.Pi {
+				sweep -= 2 * math.Pi
+			} else if sweep < -math.Pi {
+				sweep += 2 * math.Pi
+			}
+			raw = append(raw, p0)
+			steps := int(math.Ceil(math.Abs(sweep) / step))
+			for k := 1; k < steps; k++ {
+				sin, cos := math.Sincos(a0 + sweep*float64(k)/float64(steps))
+				raw = append(raw, Vec2{v.X + cos*delta, v.Y + sin*delta})
+			}
+			raw = append(raw, p1)
+		default:
+			//	the miter length (relative to `|delta|`) is `1 / cos(half the angle between n0 and n1)`
+			if 1+cos > 2/(miterLimit*miterLimit) {
+				m := Vec2{n0.X + n1.X, n0.Y + n1.Y}
+				m.Scale(delta / (1 + cos))
+				raw = append(raw, Vec2{v.X + m.X, v.Y + m.Y})
+			} else {
+				raw = append(raw, p0, p1)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,178 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Returns the point `cp` in (or on) `aabb` that is closest to `p`, and its squared distance from `p`.
+func ClosestPointOnAABB(p *Vec3, aabb *AABB) (cp *Vec3, sqDist float64) {
+	cp = aabb.ClosestPoint(p)
+	sqDist = cp.DistanceSq(p)
+	return
+}
+
+//	Returns the point `cp` in (or on) `obb` that is closest to `p`, and its squared distance from `p`.
+func ClosestPointOnOBB(p *Vec3, obb *OBB) (cp *Vec3, sqDist float64) {
+	cp = obb.ClosestPoint(p)
+	sqDist = cp.DistanceSq(p)
+	return
+}
+
+//	Returns the point `cp` on the segment from `a` to `b` that is closest to `p`, and its squared distance from `p`.
+func ClosestPointOnSegment(p, a, b *Vec3) (cp *Vec3, sqDist float64) {
+	var ab Vec3
+	ab.SetFromSub(b, a)
+	t := 0.0
+	if l := ab.Length(); l > 0 {
+		t = Clamp01(ab.DotSub(p, a) / l)
+	}
+	cp = ab.ScaledAdded(t, a)
+	sqDist = cp.DistanceSq(p)
+	return
+}
+
+//	Returns the point `cp` on the triangle `a`, `b`, `c` that is closest to `p`, and its squared distance from `p`.
+//
+//	Determines the Voronoi region of the triangle containing `p` and projects `p` onto the corresponding feature.
+func ClosestPointOnTriangle(p, a, b, c *Vec3) (cp *Vec3, sqDist float64) {
+	cp = closestPointOnTriangle(p, a, b, c)
+	sqDist = cp.DistanceSq(p)
+	return
+}
+
+func closestPointOnTriangle(p, a, b, c *Vec3) *Vec3 {
+	var ab, ac, ap, bp, cp Vec3
+	ab.SetFromSub(b, a)
+	ac.SetFromSub(c, a)
+	ap.SetFromSub(p, a)
+	d1, d2 := ab.Dot(&ap), ac.Dot(&ap)
+	if d1 <= 0 && d2 <= 0 {
+		return &Vec3{a.X, a.Y, a.Z}
+	}
+	bp.SetFromSub(p, b)
+	d3, d4 := ab.Dot(&bp), ac.Dot(&bp)
+	if d3 >= 0 && d4 <= d3 {
+		return &Vec3{b.X, b.Y, b.Z}
+	}
+	if vc := d1*d4 - d3*d2; vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return ab.ScaledAdded(d1/(d1-d3), a)
+	}
+	cp.SetFromSub(p, c)
+	d5, d6 := ab.Dot(&cp), ac.Dot(&cp)
+	if d6 >= 0 && d5 <= d6 {
+		return &Vec3{c.X, c.Y, c.Z}
+	}
+	if vb := d5*d2 - d1*d6; vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return ac.ScaledAdded(d2/(d2-d6), a)
+	}
+	if va := d3*d6 - d5*d4; va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		var bc Vec3
+		bc.SetFromSub(c, b)
+		return bc.ScaledAdded((d4-d3)/((d4-d3)+(d5-d6)), b)
+	}
+	va, vb, vc := d3*d6-d5*d4, d5*d2-d1*d6, d1*d4-d3*d2
+	denom := 1 / (va + vb + vc)
+	r := ab.ScaledAdded(vb*denom, a)
+	r.SetFromAddScaled(r, &ac, vc*denom)
+	return r
+}
+
+//	Returns the points `c1` on the segment from `p1` to `q1` and `c2` on the segment from `p2` to `q2`
+//	that are closest to each other, and their squared distance.
+func ClosestPointsSegmentSegment(p1, q1, p2, q2 *Vec3) (c1, c2 *Vec3, sqDist float64) {
+	var d1, d2, r Vec3
+	d1.SetFromSub(q1, p1)
+	d2.SetFromSub(q2, p2)
+	r.SetFromSub(p1, p2)
+	a, e, f := d1.Length(), d2.Length(), d2.Dot(&r)
+	var s, t float64
+	switch {
+	case a <= Epsilon && e <= Epsilon:
+	case a <= Epsilon:
+		t = Clamp01(f / e)
+	default:
+		c := d1.Dot(&r)
+		if e <= Epsilon {
+			s = Clamp01(-c / a)
+		} else {
+			b := d1.Dot(&d2)
+			if denom := a*e - b*b; denom != 0 {
+				s = Clamp01((b*f - c*e) / denom)
+			}
+			if t = (b*s + f) / e; t < 0 {
+				t, s = 0, Clamp01(-c/a)
+			} else if t > 1 {
+				t, s = 1, Clamp01((b-c)/a)
+			}
+		}
+	}
+	c1, c2 = d1.ScaledAdded(s, p1), d2.ScaledAdded(t, p2)
+	sqDist = c1.DistanceSq(c2)
+	return
+}
+
+//	Returns the points `cs` on the segment from `p` to `q` and `ct` on the triangle `a`, `b`, `c`
+//	that are closest to each other, and their squared distance (0 if the segment pierces the triangle).
+func ClosestPointsSegmentTriangle(p, q, a, b, c *Vec3) (cs, ct *Vec3, sqDist float64) {
+	if t, ok := IntersectSegmentTriangle(p, q, a, b, c); ok {
+		cs = Vec3_Lerp(p, q, t)
+		return cs, &Vec3{cs.X, cs.Y, cs.Z}, 0
+	}
+	sqDist = math.Inf(1)
+	tri := [3]*Vec3{a, b, c}
+	for i := range tri {
+		if s, t, d := ClosestPointsSegmentSegment(p, q, tri[i], tri[(i+1)%3]); d < sqDist {
+			cs, ct, sqDist = s, t, d
+		}
+	}
+	for _, e := range [2]*Vec3{p, q} {
+		if t, d := ClosestPointOnTriangle(e, a, b, c); d < sqDist {
+			cs, ct, sqDist = &Vec3{e.X, e.Y, e.Z}, t, d
+		}
+	}
+	return
+}
+
+//	Returns the points `c1` on the triangle `a0`, `a1`, `a2` and `c2` on the triangle `b0`, `b1`, `b2`
+//	that are closest to each other, and their squared distance (0 if the triangles intersect).
+func ClosestPointsTriangleTriangle(a0, a1, a2, b0, b1, b2 *Vec3) (c1, c2 *Vec3, sqDist float64) {
+	ta, tb := [3]*Vec3{a0, a1, a2}, [3]*Vec3{b0, b1, b2}
+	sqDist = math.Inf(1)
+	for i := 0; i < 3 && sqDist > 0; i++ {
+		if s, t, d := ClosestPointsSegmentTriangle(ta[i], ta[(i+1)%3], b0, b1, b2); d < sqDist {
+			c1, c2, sqDist = s, t, d
+		}
+		if s, t, d := ClosestPointsSegmentTriangle(tb[i], tb[(i+1)%3], a0, a1, a2); d < sqDist {
+			c1, c2, sqDist = t, s, d
+		}
+	}
+	return
+}
+
+//	Returns whether the segment from `p` to `q` intersects the triangle `a`, `b`, `c` (from either side), and if so,
+//	the parameter `t` in [0, 1] of the intersection point along the segment.
+//
+//	Segments lying in the plane of the triangle are reported as not intersecting.
+func IntersectSegmentTriangle(p, q, a, b, c *Vec3) (t float64, ok bool) {
+	var ab, ac, qp, n, ap, e Vec3
+	ab.SetFromSub(b, a)
+	ac.SetFromSub(c, a)
+	qp.SetFromSub(p, q)
+	n.SetFromCrossOf(&ab, &ac)
+	d := qp.Dot(&n)
+	if math.Abs(d) <= Epsilon*n.Length() {
+		return
+	}
+	ap.SetFromSub(p, a)
+	if t = ap.Dot(&n) / d; t < 0 || t > 1 {
+		return
+	}
+	e.SetFromCrossOf(&qp, &ap)
+	v := ac.Dot(&e) / d
+	if v < 0 || v > 1 {
+		return
+	}
+	w := -ab.Dot(&e) / d
+	ok = w >= 0 && v+w <= 1
+	return
+}
@@ -0,0 +1,279 @@
+package unum
+
+import (
+	"math"
+	"sort"
+)
+
+//	Returns the Z-order (Morton) key of `(x, y)`, interleaving the lower 16 bits of each (with `x` in the lowest bit).
+func Morton2Encode32(x, y uint32) uint32 {
+	return uint32(mortonSpread2(uint64(x&0xffff)) | mortonSpread2(uint64(y&0xffff))<<1)
+}
+
+//	Returns the coordinates whose `Morton2Encode32` key is `key`.
+func Morton2Decode32(key uint32) (x, y uint32) {
+	return uint32(mortonCompact2(uint64(key))), uint32(mortonCompact2(uint64(key) >> 1))
+}
+
+//	Returns the Z-order (Morton) key of `(x, y)`, interleaving all 32 bits of each (with `x` in the lowest bit).
+func Morton2Encode64(x, y uint32) uint64 {
+	return mortonSpread2(uint64(x)) | mortonSpread2(uint64(y))<<1
+}
+
+//	Returns the coordinates whose `Morton2Encode64` key is `key`.
+func Morton2Decode64(key uint64) (x, y uint32) {
+	return uint32(mortonCompact2(key)), uint32(mortonCompact2(key >> 1))
+}
+
+//	Returns the Z-order (Morton) key of `(x, y, z)`, interleaving the lower 10 bits of each (with `x` in the lowest bit).
+func Morton3Encode32(x, y, z uint32) uint32 {
+	return uint32(mortonSpread3(uint64(x&0x3ff)) | mortonSpread3(uint64(y&0x3ff))<<1 | mortonSpread3(uint64(z&0x3ff))<<2)
+}
+
+//	Returns the coordinates whose `Morton3Encode32` key is `key`.
+func Morton3Decode32(key uint32) (x, y, z uint32) {
+	k := uint64(key)
+	return uint32(mortonCompact3(k)), uint32(mortonCompact3(k >> 1)), uint32(mortonCompact3(k >> 2))
+}
+
+//	Returns the Z-order (Morton) key of `(x, y, z)`, interleaving the lower 21 bits of each (with `x` in the lowest bit).
+func Morton3Encode64(x, y, z uint32) uint64 {
+	return mortonSpread3(uint64(x)) | mortonSpread3(uint64(y))<<1 | mortonSpread3(uint64(z))<<2
+}
+
+//	Returns the coordinates whose `Morton3Encode64` key is `key`.
+func Morton3Decode64(key uint64) (x, y, z uint32) {
+	return uint32(mortonCompact3(key)), uint32(mortonCompact3(key >> 1)), uint32(mortonCompact3(key >> 2))
+}
+
+//	Returns the distance of `(x, y)` along the 2D Hilbert curve over a grid of 2^16 cells per axis
+//	(only the lower 16 bits of each coordinate are used). Consecutive keys always map to adjacent cells.
+func Hilbert2Encode32(x, y uint32) uint32 {
+	return uint32(hilbertEncode([]uint32{x & 0xffff, y & 0xffff}, 16))
+}
+
+//	Returns the coordinates whose `Hilbert2Encode32` key is `key`.
+func Hilbert2Decode32(key uint32) (x, y uint32) {
+	var c [2]uint32
+	hilbertDecode(uint64(key), c[:], 16)
+	return c[0], c[1]
+}
+
+//	Returns the distance of `(x, y)` along the 2D Hilbert curve over a grid of 2^32 cells per axis.
+func Hilbert2Encode64(x, y uint32) uint64 {
+	return hilbertEncode([]uint32{x, y}, 32)
+}
+
+//	Returns the coordinates whose `Hilbert2Encode64` key is `key`.
+func Hilbert2Decode64(key uint64) (x, y uint32) {
+	var c [2]uint32
+	hilbertDecode(key, c[:], 32)
+	return c[0], c[1]
+}
+
+//	Returns the distance of `(x, y, z)` along the 3D Hilbert curve over a grid of 2^10 cells per axis
+//	(only the lower 10 bits of each coordinate are used). Consecutive keys always map to adjacent cells.
+func Hilbert3Encode32(x, y, z uint32) uint32 {
+	return uint32(hilbertEncode([]uint32{x & 0x3ff, y & 0x3ff, z & 0x3ff}, 10))
+}
+
+//	Returns the coordinates whose `Hilbert3Encode32` key is `key`.
+func Hilbert3Decode32(key uint32) (x, y, z uint32) {
+	var c [3]uint32
+	hilbertDecode(uint64(key), c[:], 10)
+	return c[0], c[1], c[2]
+}
+
+//	Returns the distance of `(x, y, z)` along the 3D Hilbert curve over a grid of 2^21 cells per axis
+//	(only the lower 21 bits of each coordinate are used).
+func Hilbert3Encode64(x, y, z uint32) uint64 {
+	return hilbertEncode([]uint32{x & 0x1fffff, y & 0x1fffff, z & 0x1fffff}, 21)
+}
+
+//	Returns the coordinates whose `Hilbert3Encode64` key is `key`.
+func Hilbert3Decode64(key uint64) (x, y, z uint32) {
+	var c [3]uint32
+	hilbertDecode(key, c[:], 21)
+	return c[0], c[1], c[2]
+}
+
+//	Returns the number of bits per axis needed to quantize to a grid of at least `resolution` cells per axis.
+func CurveBits(resolution uint32) (bits uint) {
+	if resolution > 1<<31 {
+		//	`NextPowerOfTwo` would overflow to 0
+		return 32
+	} else if resolution > 1 {
+		for n := NextPowerOfTwo(resolution); n > 1; n >>= 1 {
+			bits++
+		}
+	}
+	return
+}
+
+//	Returns the integer cell coordinates of `pos` within a grid of 2^`bits` cells per axis spanning `me`.
+//	Positions outside `me` are clamped to its boundary cells, as are all coordinates of degenerate (flat) axes.
+func (me *AABB) Quantize(pos *Vec3, bits uint) (x, y, z uint32) {
+	cells := float64(uint64(1) << bits)
+	q := func(v, min, max float64) uint32 {
+		if max <= min {
+			return 0
+		}
+		return uint32(math.Max(0, math.Min(cells-1, math.Floor((v-min)/(max-min)*cells))))
+	}
+	return q(pos.X, me.Min.X, me.Max.X), q(pos.Y, me.Min.Y, me.Max.Y), q(pos.Z, me.Min.Z, me.Max.Z)
+}
+
+//	Returns the `Morton3Encode32` key of `pos` quantized against `me` to 10 bits per axis.
+func (me *AABB) MortonKey32(pos *Vec3) uint32 {
+	return Morton3Encode32(me.Quantize(pos, 10))
+}
+
+//	Returns the `Morton3Encode64` key of `pos` quantized against `me` to 21 bits per axis.
+func (me *AABB) MortonKey64(pos *Vec3) uint64 {
+	return Morton3Encode64(me.Quantize(pos, 21))
+}
+
+//	Returns the `Hilbert3Encode32` key of `pos` quantized against `me` to 10 bits per axis.
+func (me *AABB) HilbertKey32(pos *Vec3) uint32 {
+	return Hilbert3Encode32(me.Quantize(pos, 10))
+}
+
+//	Returns the `Hilbert3Encode64` key of `pos` quantized against `me` to 21 bits per axis.
+func (me *AABB) HilbertKey64(pos *Vec3) uint64 {
+	return Hilbert3Encode64(me.Quantize(pos, 21))
+}
+
+//	Returns the permutation of indices into `keys` that orders them ascending, keeping equal keys in their original order.
+func CurveKeyOrder(keys []uint64) (order []int) {
+	order = make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] < keys[order[j]] })
+	return
+}
+
+//	Sorts `keys` ascending in place, calling `swap` for every swap of two keys so that a parallel slice of objects
+//	can be kept in the same order.
+func SortByCurveKey(keys []uint64, swap func(i, j int)) {
+	sort.Sort(&curveKeySorter{keys, swap})
+}
+
+type curveKeySorter struct {
+	keys []uint64
+	swap func(i, j int)
+}
+
+func (me *curveKeySorter) Len() int { return len(me.keys) }
+
+func (me *curveKeySorter) Less(i, j int) bool { return me.keys[i] < me.keys[j] }
+
+func (me *curveKeySorter) Swap(i, j int) {
+	me.keys[i], me.keys[j] = me.keys[j], me.keys[i]
+	me.swap(i, j)
+}
+
+//	Spreads the lower 32 bits of `v` to the even bits of the result.
+func mortonSpread2(v uint64) uint64 {
+	v &= 0xffffffff
+	v = (v | v<<16) & 0x0000ffff0000ffff
+	v = (v | v<<8) & 0x00ff00ff00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v<<2) & 0x3333333333333333
+	return (v | v<<1) & 0x5555555555555555
+}
+
+//	The inverse of `mortonSpread2`: gathers the even bits of `v`.
+func mortonCompact2(v uint64) uint64 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v>>4) & 0x00ff00ff00ff00ff
+	v = (v | v>>8) & 0x0000ffff0000ffff
+	return (v | v>>16) & 0xffffffff
+}
+
+//	Spreads the lower 21 bits of `v` to every third bit of the result.
+func mortonSpread3(v uint64) uint64 {
+	v &= 0x1fffff
+	v = (v | v<<32) & 0x001f00000000ffff
+	v = (v | v<<16) & 0x001f0000ff0000ff
+	v = (v | v<<8) & 0x100f00f00f00f00f
+	v = (v | v<<4) & 0x10c30c30c30c30c3
+	return (v | v<<2) & 0x1249249249249249
+}
+
+//	The inverse of `mortonSpread3`: gathers every third bit of `v`.
+func mortonCompact3(v uint64) uint64 {
+	v &= 0x1249249249249249
+	v = (v | v>>2) & 0x10c30c30c30c30c3
+	v = (v | v>>4) & 0x100f00f00f00f00f
+	v = (v | v>>8) & 0x001f0000ff0000ff
+	v = (v | v>>16) & 0x001f00000000ffff
+	return (v | v>>32) & 0x1fffff
+}
+
+//	Returns the Hilbert key of `coords` (each `bits` wide), using Skilling's transposition: the axes are
+//	transformed in place into the "transposed" key, whose bits are then interleaved with `coords[0]` most significant.
+func hilbertEncode(coords []uint32, bits uint) (key uint64) {
+	n := len(coords)
+	for q := uint32(1) << (bits - 1); q > 1; q >>= 1 {
+		p := q - 1
+		for i := 0; i < n; i++ {
+			if coords[i]&q != 0 {
+				coords[0] ^= p
+			} else {
+				t := (coords[0] ^ coords[i]) & p
+				coords[0], coords[i] = coords[0]^t, coords[i]^t
+			}
+		}
+	}
+	for i := 1; i < n; i++ {
+		coords[i] ^= coords[i-1]
+	}
+	var t uint32
+	for q := uint32(1) << (bits - 1); q > 1; q >>= 1 {
+		if coords[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+	for i := range coords {
+		coords[i] ^= t
+	}
+	for b := int(bits) - 1; b >= 0; b-- {
+		for i := 0; i < n; i++ {
+			key = key<<1 | uint64(coords[i]>>uint(b)&1)
+		}
+	}
+	return
+}
+
+//	The inverse of `hilbertEncode`, storing the decoded axes in `coords`.
+func hilbertDecode(key uint64, coords []uint32, bits uint) {
+	n := len(coords)
+	for i := range coords {
+		coords[i] = 0
+	}
+	for b, k := int(bits)-1, uint(n)*bits; b >= 0; b-- {
+		for i := 0; i < n; i++ {
+			k--
+			coords[i] |= uint32(key>>k&1) << uint(b)
+		}
+	}
+	t := coords[n-1] >> 1
+	for i := n - 1; i > 0; i-- {
+		coords[i] ^= coords[i-1]
+	}
+	coords[0] ^= t
+	for q := uint64(2); q != uint64(1)<<bits; q <<= 1 {
+		p := uint32(q - 1)
+		for i := n - 1; i >= 0; i-- {
+			if coords[i]&uint32(q) != 0 {
+				coords[0] ^= p
+			} else {
+				t := (coords[0] ^ coords[i]) & p
+				coords[0], coords[i] = coords[0]^t, coords[i]^t
+			}
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package unum
+
+import (
+	"math"
+)
+
+type dtTri struct {
+	v, adj [3]int
+	dead   bool
+}
+
+type delaunay struct {
+	pts   []Vec2
+	tris  []dtTri
+	alias []int
+	last  int
+}
+
+//	Computes the constrained Delaunay triangulation of `points` with the Bowyer-Watson algorithm, then inserts the
+//	`constraints` (an index buffer into `points`, 2 indices per segment) by re-triangulating the regions they cross.
+//
+//	Returns the counter-clockwise triangles covering the convex hull of `points` as an index buffer into `points`,
+//	3 indices per triangle. Duplicate points are ignored (only the first occurrence is referenced). Constraints
+//	should not cross each other; a constraint passing through other points is split at those.
+func TriangulateDelaunay(points []Vec2, constraints []uint32) (indices []uint32) {
+	if len(points) < 3 {
+		return
+	}
+	me := &delaunay{pts: append(make([]Vec2, 0, len(points)+3), points...), alias: make([]int, len(points))}
+	//	a super-triangle far outside the bounds, removed again at the end
+	min, max := Polygon(points).Bounds()
+	c, size := Vec2{0.5 * (min.X + max.X), 0.5 * (min.Y + max.Y)}, math.Max(math.Max(max.X-min.X, max.Y-min.Y), 1)*1000
+	s := len(points)
+	me.pts = append(me.pts, Vec2{c.X - 2*size, c.Y - size}, Vec2{c.X + 2*size, c.Y - size}, Vec2{c.X, c.Y + 2*size})
+	me.tris = []dtTri{{v: [3]int{s, s + 1, s + 2}, adj: [3]int{-1, -1, -1}}}
+	for i := range points {
+		me.alias[i] = me.insert(i)
+	}
+	for k := 0; k+1 < len(constraints); k += 2 {
+		if a, b := me.alias[constraints[k]], me.alias[constraints[k+1]]; a != b {
+			me.constrain(a, b)
+		}
+	}
+	for t := range me.tris {
+		if tri := &me.tris[t]; !tri.dead && tri.v[0] < s && tri.v[1] < s && tri.v[2] < s {
+			indices = append(indices, uint32(tri.v[0]), uint32(tri.v[1]), uint32(tri.v[2]))
+		}
+	}
+	return
+}
+
+//	Returns the index of the live triangle containing `p`, walking from the most recently created one.
+func (me *delaunay) locate(p *Vec2) int {
+	t := me.last
+	for steps := 0; steps < len(me.tris); steps++ {
+		tri, moved := &me.tris[t], false
+		for e := 0; e < 3; e++ {
+			if tri.adj[e] >= 0 && Orient2D(&me.pts[tri.v[e]], &me.pts[tri.v[(e+1)%3]], p) < 0 {
+				t, moved = tri.adj[e], true
+				break
+			}
+		}
+		if !moved {
+			return t
+		}
+	}
+	//	the walk cycled (possible with degenerate input): fall back to a linear scan
+	for t = range me.tris {
+		if tri := &me.tris[t]; !tri.dead && Orient2D(&me.pts[tri.v[0]], &me.pts[tri.v[1]], p) >= 0 &&
+			Orient2D(&me.pts[tri.v[1]], &me.pts[tri.v[2]], p) >= 0 && Orient2D(&me.pts[tri.v[2]], &me.pts[tri.v[0]], p) >= 0 {
+			break
+		}
+	}
+	return t
+}
+
+//	Inserts point `i` and returns its index, or that of an identical existing vertex.
+func (me *delaunay) insert(i int) int {
+	p := &me.pts[i]
+	t := me.locate(p)
+	for _, v := range me.tris[t].v {
+		if me.pts[v] == *p {
+			return v
+		}
+	}
+	//	the cavity: all triangles connected to `t` whose circumcircle contains `p`
+	type edge struct{ a, b, nbr int }
+	var boundary []edge
+	stack := []int{t}
+	me.tris[t].dead = true
+	for len(stack) > 0 {
+		cur := &me.tris[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		for e := 0; e < 3; e++ {
+			nbr := cur.adj[e]
+			if nbr >= 0 && me.tris[nbr].dead {
+				continue
+			}
+			if nbr >= 0 && me.inCircle(nbr, p) {
+				me.tris[nbr].dead = true
+				stack = append(stack, nbr)
+			} else {
+				boundary = append(boundary, edge{cur.v[e], cur.v[(e+1)%3], nbr})
+			}
+		}
+	}
+	//	fan the cavity boundary around `p`
+	first, byStart := len(me.tris), make(map[int]int, len(boundary))
+	for k, e := range boundary {
+		byStart[e.a] = first + k
+		me.tris = append(me.tris, dtTri{v: [3]int{e.a, e.b, i}, adj: [3]int{e.nbr, -1, -1}})
+		if e.nbr >= 0 {
+			me.relink(e.nbr, e.b, e.a, first+k)
+		}
+	}
+	for t := first; t < len(me.tris); t++ {
+		tri := &me.tris[t]
+		tri.adj[1] = byStart[tri.v[1]]
+		me.tris[tri.adj[1]].adj[2] = t
+	}
+	me.last = first
+	return i
+}
+
+func (me *delaunay) inCircle(t int, p *Vec2) bool {
+	tri := &me.tris[t]
+	return InCircle(&me.pts[tri.v[0]], &me.pts[tri.v[1]], &me.pts[tri.v[2]], p) > 0
+}
+
+//	Points the adjacency of triangle `t` across its edge from `a` to `b` at `nbr`.
+func (me *delaunay) relink(t, a, b, nbr int) {
+	tri := &me.tris[t]
+	for e := 0; e < 3; e++ {
+		if tri.v[e] == a && tri.v[(e+1)%3] == b {
+			tri.adj[e] = nbr
+		}
+	}
+}
+
+//	Forces the edge from `a` to `b` into the triangulation.
+func (me *delaunay) constrain(a, b int) {
+	for a != b {
+		t, e, via := me.crossing(a, b)
+		if t < 0 {
+			if via < 0 {
+				return
+			}
+			a = via
+			continue
+		}
+		//	march along the segment, collecting the crossed triangles and the vertices on both sides
+		var upper, lower []int
+		for {
+			tri := &me.tris[t]
+			x, y := tri.v[e], tri.v[(e+1)%3]
+			tri.dead = true
+			if len(lower) == 0 || lower[len(lower)-1] != x {
+				lower = append(lower, x)
+			}
+			if len(upper) == 0 || upper[len(upper)-1] != y {
+				upper = append(upper, y)
+			}
+			nbr := tri.adj[e]
+			ntri, k := &me.tris[nbr], 0
+			for ntri.v[k] == x || ntri.v[k] == y {
+				k++
+			}
+			if z := ntri.v[k]; z == b || me.collinearAhead(a, b, z) {
+				ntri.dead, via = true, z
+				break
+			} else if Orient2D(&me.pts[a], &me.pts[b], &me.pts[z]) > 0 {
+				t, e = nbr, me.edgeIndex(nbr, x, z)
+			} else {
+				t, e = nbr, me.edgeIndex(nbr, z, y)
+			}
+		}
+		for i, j := 0, len(lower)-1; i < j; i, j = i+1, j-1 {
+			lower[i], lower[j] = lower[j], lower[i]
+		}
+		me.fillPseudo(upper, a, via)
+		me.fillPseudo(lower, via, a)
+		me.rebuildAdjacency()
+		a = via
+	}
+}
+
+//	Finds where the segment from `a` to `b` leaves vertex `a`: either the triangle `t` whose edge `e` (opposite `a`)
+//	it crosses, or (with `t` of -1) the vertex `via` adjacent to `a` it runs through, which is `b` itself if the
+//	edge already exists. Both `t` and `via` are -1 if `a` is not part of the triangulation.
+func (me *delaunay) crossing(a, b int) (t, e, via int) {
+	t, e, via = -1, -1, -1
+	pa, pb := &me.pts[a], &me.pts[b]
+	for ti := range me.tris {
+		tri := &me.tris[ti]
+		for k := 0; k < 3 && !tri.dead; k++ {
+			if tri.v[k] != a {
+				continue
+			}
+			x, y := tri.v[(k+1)%3], tri.v[(k+2)%3]
+			if x == b || me.collinearAhead(a, b, x) {
+				return -1, -1, x
+			} else if y == b || me.collinearAhead(a, b, y) {
+				return -1, -1, y
+			} else if Orient2D(pa, pb, &me.pts[x]) < 0 && Orient2D(pa, pb, &me.pts[y]) > 0 {
+				t, e = ti, (k+1)%3
+			}
+		}
+	}
+	return
+}
+
+//	Returns whether vertex `v` lies on the segment from `a` to `b` (exclusively).
+func (me *delaunay) collinearAhead(a, b, v int) bool {
+	pa, pb, pv := &me.pts[a], &me.pts[b], &me.pts[v]
+	d := pb.Sub(pa)
+	t := pv.Sub(pa).Dot(d)
+	return Orient2D(pa, pb, pv) == 0 && t > 0 && t < d.Length()
+}
+
+//	Returns the index of the edge from `a` to `b` in triangle `t`.
+func (me *delaunay) edgeIndex(t, a, b int) int {
+	for e := 0; e < 3; e++ {
+		if tri := &me.tris[t]; tri.v[e] == a && tri.v[(e+1)%3] == b {
+			return e
+		}
+	}
+	return -1
+}
+
+//	Triangulates the pseudo-polygon bounded by the edge from `a` to `b` and the chain `poly` (ordered from `a` to `b`,
+//	all left of that edge) by recursively picking the chain vertex whose circumcircle with `a` and `b` is empty.
+func (me *delaunay) fillPseudo(poly []int, a, b int) {
+	if len(poly) == 0 {
+		return
+	}
+	c := 0
+	for i := 1; i < len(poly); i++ {
+		if InCircle(&me.pts[a], &me.pts[b], &me.pts[poly[c]], &me.pts[poly[i]]) > 0 {
+			c = i
+		}
+	}
+	me.tris = append(me.tris, dtTri{v: [3]int{a, b, poly[c]}, adj: [3]int{-1, -1, -1}})
+	me.fillPseudo(poly[:c], a, poly[c])
+	me.fillPseudo(poly[c+1:], poly[c], b)
+}
+
+func (me *delaunay) rebuildAdjacency() {
+	edges := make(map[[2]int]int, len(me.tris)*3)
+	for t := range me.tris {
+		if tri := &me.tris[t]; !tri.dead {
+			for e := 0; e < 3; e++ {
+				edges[[2]int{tri.v[e], tri.v[(e+1)%3]}] = t
+			}
+		}
+	}
+	for t := range me.tris {
+		if tri := &me.tris[t]; !tri.dead {
+			me.last = t
+			for e := 0; e < 3; e++ {
+				if nbr, ok := edges[[2]int{tri.v[(e+1)%3], tri.v[e]}]; ok {
+					tri.adj[e] = nbr
+				} else {
+					tri.adj[e] = -1
+				}
+			}
+		}
+	}
+}
+
+//	Returns the center of the circle through `a`, `b` and `c`, or their average if they are collinear.
+func circumcenter(a, b, c *Vec2) *Vec2 {
+	bx, by, cx, cy := b.X-a.X, b.Y-a.Y, c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		return &Vec2{(a.X + b.X + c.X) / 3, (a.Y + b.Y + c.Y) / 3}
+	}
+	bb, cc := bx*bx+by*by, cx*cx+cy*cy
+	return &Vec2{a.X + (cy*bb-by*cc)/d, a.Y + (bx*cc-cx*bb)/d}
+}
+
+//	Represents the Voronoi region of one site, as extracted by `Voronoi`.
+type VoronoiCell struct {
+	//	The region's corners (circumcenters of the Delaunay triangles around the site), counter-clockwise.
+	Vertices Polygon
+
+	//	Whether the region is unbounded, as for all sites on the convex hull.
+	Open bool
+
+	//	For `Open` regions: the directions of the 2 unbounded edges, which start at
+	//	`Vertices[0]` and at the last of the `Vertices`.
+	Rays [2]Vec2
+}
+
+//	Extracts the Voronoi diagram dual to the (unconstrained) Delaunay triangulation `indices` of `points`,
+//	as returned by `TriangulateDelaunay`. Returns one `VoronoiCell` per point; cells of points not referenced
+//	by any triangle are empty.
+func Voronoi(points []Vec2, indices []uint32) (cells []VoronoiCell) {
+	cells = make([]VoronoiCell, len(points))
+	centers := make([]Vec2, len(indices)/3)
+	edges := make(map[[2]uint32]int, len(indices))
+	for t := range centers {
+		i := indices[t*3 : t*3+3]
+		centers[t] = *circumcenter(&points[i[0]], &points[i[1]], &points[i[2]])
+		for e := 0; e < 3; e++ {
+			edges[[2]uint32{i[e], i[(e+1)%3]}] = t
+		}
+	}
+	done := make([]bool, len(points))
+	for t := range centers {
+		for k := 0; k < 3; k++ {
+			v := indices[t*3+k]
+			if done[v] {
+				continue
+			}
+			done[v] = true
+			cell := &cells[v]
+			//	`from(t)` and `to(t)` are the other 2 vertices of triangle `t`, counter-clockwise after `v`
+			from := func(t int) uint32 {
+				for e := 0; e < 3; e++ {
+					if indices[t*3+e] == v {
+						return indices[t*3+(e+1)%3]
+					}
+				}
+				return v
+			}
+			to := func(t int) uint32 {
+				for e := 0; e < 3; e++ {
+					if indices[t*3+e] == v {
+						return indices[t*3+(e+2)%3]
+					}
+				}
+				return v
+			}
+			//	rewind clockwise to the hull (if any), then collect counter-clockwise
+			start := t
+			for cw, ok := edges[[2]uint32{from(start), v}]; ok && cw != t; cw, ok = edges[[2]uint32{from(start), v}] {
+				start = cw
+			}
+			cur := start
+			for {
+				cell.Vertices = append(cell.Vertices, centers[cur])
+				next, ok := edges[[2]uint32{v, to(cur)}]
+				if !ok {
+					cell.Open = true
+					break
+				}
+				if cur = next; cur == start {
+					break
+				}
+			}
+			if cell.Open {
+				//	outward, perpendicular to the hull edges at both ends
+				d0, d1 := points[from(start)].Sub(&points[v]), points[v].Sub(&points[to(cur)])
+				cell.Rays[0], cell.Rays[1] = Vec2{d0.Y, -d0.X}, Vec2{d1.Y, -d1.X}
+				cell.Rays[0].NormalizeSafe()
+				cell.Rays[1].NormalizeSafe()
+			}
+		}
+	}
+	return
+}
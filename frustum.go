@@ -0,0 +1,57 @@
+package unum
+
+//	Represents a view frustum as its 6 bounding planes (left, right, bottom, top, near, far), all facing inwards.
+type Frustum [6]Plane
+
+//	Returns a new `*Frustum` extracted from the combined view-projection matrix `mat` (Gribb-Hartmann),
+//	in the space that `mat` transforms from (ie. world space for a view-projection matrix).
+func NewFrustum(mat *Mat4) (me *Frustum) {
+	me = new(Frustum)
+	for i := 0; i < 6; i++ {
+		//	row 3 plus or minus row 0, 1 or 2 (of the column-major `mat`)
+		r, s := i/2, 1.0
+		if i%2 == 1 {
+			s = -1
+		}
+		n := Vec3{mat[3] + s*mat[r], mat[7] + s*mat[4+r], mat[11] + s*mat[8+r]}
+		d := mat[15] + s*mat[12+r]
+		if l := n.Magnitude(); l != 0 {
+			n.Scale(1 / l)
+			d /= l
+		}
+		me[i] = Plane{Normal: n, Dist: -d}
+	}
+	return
+}
+
+//	Returns whether `point` lies within (or on the boundary of) `me`.
+func (me *Frustum) ContainsPoint(point *Vec3) bool {
+	for i := range me {
+		if me[i].SignedDistance(point) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns whether `aabb` overlaps `me`. This conservative test may report overlap for some boxes
+//	near the frustum's edges that lie outside of it.
+func (me *Frustum) IntersectsAABB(aabb *AABB) bool {
+	for i := range me {
+		if p := aabb.Support(me[i].Normal); me[i].SignedDistance(&p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns whether the sphere at `center` with `radius` overlaps `me`. This conservative test may report
+//	overlap for some spheres near the frustum's edges that lie outside of it.
+func (me *Frustum) IntersectsSphere(center *Vec3, radius float64) bool {
+	for i := range me {
+		if me[i].SignedDistance(center) < -radius {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,379 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Implemented by convex shapes that can participate in GJK/EPA collision queries.
+type ConvexShape interface {
+	//	Returns the point of the shape that is farthest along `dir` (which need not be normalized).
+	Support(dir Vec3) Vec3
+}
+
+var (
+	//	The maximum number of iterations performed by the GJK and EPA routines.
+	GjkMaxIter = 64
+
+	//	The relative tolerance at which the GJK and EPA routines consider themselves converged.
+	GjkTolerance = 1E-08
+)
+
+//	A vertex of the Minkowski difference `a - b`, together with the support points on `a` and `b` that produced it.
+type gjkVertex struct {
+	w, a, b Vec3
+}
+
+func gjkSupport(a, b ConvexShape, dir *Vec3) (v gjkVertex) {
+	v.a, v.b = a.Support(*dir), b.Support(Vec3{-dir.X, -dir.Y, -dir.Z})
+	v.w.SetFromSub(&v.a, &v.b)
+	return
+}
+
+type gjkSimplex struct {
+	v  [4]gjkVertex
+	bc [4]float64
+	n  int
+}
+
+//	Interpolates the support points on both shapes with the barycentric weights of the closest point.
+func (me *gjkSimplex) witnesses() (pa, pb *Vec3) {
+	pa, pb = new(Vec3), new(Vec3)
+	for i := 0; i < me.n; i++ {
+		pa.SetFromAddScaled(pa, &me.v[i].a, me.bc[i])
+		pb.SetFromAddScaled(pb, &me.v[i].b, me.bc[i])
+	}
+	return
+}
+
+//	Keeps only the simplex vertices at the specified indices, with the specified barycentric weights.
+func (me *gjkSimplex) reduce(idx []int, bc []float64) {
+	var v [4]gjkVertex
+	for i, j := range idx {
+		v[i] = me.v[j]
+	}
+	me.v, me.n = v, len(idx)
+	copy(me.bc[:], bc)
+}
+
+//	Reduces `me` to the smallest sub-simplex containing the point closest to the origin, and returns that point.
+//	Returns `inside` if `me` is a tetrahedron containing the origin.
+func (me *gjkSimplex) solve() (closest Vec3, inside bool) {
+	switch me.n {
+	case 1:
+		me.bc[0] = 1
+	case 2:
+		me.solveSegment(0, 1)
+	case 3:
+		me.solveTriangle(0, 1, 2)
+	case 4:
+		inside = me.solveTetrahedron()
+	}
+	for i := 0; i < me.n; i++ {
+		closest.SetFromAddScaled(&closest, &me.v[i].w, me.bc[i])
+	}
+	return
+}
+
+func (me *gjkSimplex) solveSegment(i0, i1 int) float64 {
+	a, b := &me.v[i0].w, &me.v[i1].w
+	var ab Vec3
+	ab.SetFromSub(b, a)
+	l := ab.Length()
+	t := 0.0
+	if l > 0 {
+		t = -a.Dot(&ab) / l
+	}
+	switch {
+	case t <= 0:
+		me.reduce([]int{i0}, []float64{1})
+		return a.Length()
+	case t >= 1:
+		me.reduce([]int{i1}, []float64{1})
+		return b.Length()
+	}
+	me.reduce([]int{i0, i1}, []float64{1 - t, t})
+	return ab.ScaledAdded(t, a).Length()
+}
+
+//	Ericson's closest-point-on-triangle region tests, specialized for the origin.
+//	Returns the squared distance of the closest point from the origin.
+func (me *gjkSimplex) solveTriangle(i0, i1, i2 int) float64 {
+	a, b, c := &me.v[i0].w, &me.v[i1].w, &me.v[i2].w
+	var ab, ac Vec3
+	ab.SetFromSub(b, a)
+	ac.SetFromSub(c, a)
+	d1, d2 := -ab.Dot(a), -ac.Dot(a)
+	if d1 <= 0 && d2 <= 0 {
+		me.reduce([]int{i0}, []float64{1})
+		return a.Length()
+	}
+	d3, d4 := -ab.Dot(b), -ac.Dot(b)
+	if d3 >= 0 && d4 <= d3 {
+		me.reduce([]int{i1}, []float64{1})
+		return b.Length()
+	}
+	if vc := d1*d4 - d3*d2; vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return me.solveSegment(i0, i1)
+	}
+	d5, d6 := -ab.Dot(c), -ac.Dot(c)
+	if d6 >= 0 && d5 <= d6 {
+		me.reduce([]int{i2}, []float64{1})
+		return c.Length()
+	}
+	if vb := d5*d2 - d1*d6; vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return me.solveSegment(i0, i2)
+	}
+	if va := d3*d6 - d5*d4; va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		return me.solveSegment(i1, i2)
+	}
+	va, vb, vc := d3*d6-d5*d4, d5*d2-d1*d6, d1*d4-d3*d2
+	if denom := va + vb + vc; denom != 0 {
+		v, w := vb/denom, vc/denom
+		me.reduce([]int{i0, i1, i2}, []float64{1 - v - w, v, w})
+		p := ab.ScaledAdded(v, a)
+		p.SetFromAddScaled(p, &ac, w)
+		return p.Length()
+	}
+	//	degenerate (collinear) triangle
+	return me.solveSegment(i0, i1)
+}
+
+func (me *gjkSimplex) solveTetrahedron() bool {
+	faces := [4][4]int{{0, 1, 2, 3}, {0, 3, 1, 2}, {0, 2, 3, 1}, {1, 3, 2, 0}}
+	var ab, ac, n, ad Vec3
+	best, bestSq, saved := -1, math.Inf(1), *me
+	for f, face := range faces {
+		a := &saved.v[face[0]].w
+		ab.SetFromSub(&saved.v[face[1]].w, a)
+		ac.SetFromSub(&saved.v[face[2]].w, a)
+		ad.SetFromSub(&saved.v[face[3]].w, a)
+		n.SetFromCrossOf(&ab, &ac)
+		//	the origin is outside this face if it lies on the other side than the opposite vertex
+		if sOrigin, sOpp := -n.Dot(a), n.Dot(&ad); sOrigin*sOpp < 0 || sOpp == 0 {
+			*me = saved
+			if sq := me.solveTriangle(face[0], face[1], face[2]); sq < bestSq {
+				best, bestSq = f, sq
+			}
+		}
+	}
+	if best < 0 {
+		*me = saved
+		return true
+	}
+	*me = saved
+	me.solveTriangle(faces[best][0], faces[best][1], faces[best][2])
+	return false
+}
+
+//	Runs GJK on the Minkowski difference `a - b`. Returns the final `simplex`, the point `v` of the Minkowski
+//	difference closest to the origin, and whether the shapes `hit`. If `early`, returns as soon as a separating axis is found.
+func gjk(a, b ConvexShape, early bool) (simplex gjkSimplex, v Vec3, hit bool) {
+	var inside bool
+	v = Vec3{1, 0, 0}
+	simplex.v[0] = gjkSupport(a, b, &v)
+	simplex.n, simplex.bc[0] = 1, 1
+	v = simplex.v[0].w
+	for i := 0; i < GjkMaxIter; i++ {
+		vv, scale := v.Length(), 0.0
+		for j := 0; j < simplex.n; j++ {
+			scale = math.Max(scale, simplex.v[j].w.Length())
+		}
+		if vv <= GjkTolerance*GjkTolerance*math.Max(1, scale) {
+			return simplex, v, true
+		}
+		dir := v.Negated()
+		w := gjkSupport(a, b, dir)
+		if early && w.w.Dot(dir) < 0 {
+			return simplex, v, false
+		}
+		if vv-v.Dot(&w.w) <= GjkTolerance*vv {
+			return simplex, v, false
+		}
+		for j := 0; j < simplex.n; j++ {
+			if simplex.v[j].w.Eq(&w.w) {
+				return simplex, v, false
+			}
+		}
+		simplex.v[simplex.n] = w
+		simplex.n++
+		if v, inside = simplex.solve(); inside {
+			return simplex, Vec3{}, true
+		}
+	}
+	return simplex, v, false
+}
+
+//	Returns whether the convex shapes `a` and `b` overlap (or touch), using the GJK algorithm.
+func GjkIntersect(a, b ConvexShape) bool {
+	_, _, hit := gjk(a, b, true)
+	return hit
+}
+
+//	Returns the distance between the convex shapes `a` and `b` plus the closest points `pa` on `a` and `pb` on `b`,
+//	using the GJK algorithm. If the shapes overlap, `dist` is 0 and `pa` and `pb` are undefined (see `EpaPenetration`).
+func GjkDistance(a, b ConvexShape) (dist float64, pa, pb *Vec3) {
+	simplex, v, hit := gjk(a, b, false)
+	if pa, pb = simplex.witnesses(); !hit {
+		dist = v.Magnitude()
+	}
+	return
+}
+
+type epaFace struct {
+	i [3]int
+	n Vec3
+	d float64
+}
+
+//	Computes the penetration of the overlapping convex shapes `a` and `b`, by running GJK followed by the
+//	Expanding Polytope Algorithm. Returns the penetration `depth`, the unit contact `normal` (pointing from `a`
+//	towards `b`; translating `b` by `normal * depth` separates the shapes) and the deepest points `pa` on `a` and `pb` on `b`.
+//
+//	Returns `ok` as `false` if the shapes don't overlap, or the penetration cannot be determined (eg. for flat shapes).
+func EpaPenetration(a, b ConvexShape) (depth float64, normal, pa, pb *Vec3, ok bool) {
+	simplex, _, hit := gjk(a, b, false)
+	if !hit || !epaTetrahedron(a, b, &simplex) {
+		return
+	}
+	verts := append([]gjkVertex{}, simplex.v[:]...)
+	var faces []epaFace
+	var centroid Vec3
+	for i := range verts {
+		centroid.SetFromAddScaled(&centroid, &verts[i].w, 0.25)
+	}
+	for _, f := range [4][3]int{{0, 1, 2}, {0, 3, 1}, {0, 2, 3}, {1, 3, 2}} {
+		face := epaMakeFace(verts, f[0], f[1], f[2])
+		if face.n.DotSub(&verts[f[0]].w, &centroid) < 0 {
+			face = epaMakeFace(verts, f[0], f[2], f[1])
+		}
+		faces = append(faces, face)
+	}
+	var best *epaFace
+	for iter := 0; iter < GjkMaxIter; iter++ {
+		best = &faces[0]
+		for i := range faces {
+			if faces[i].d < best.d {
+				best = &faces[i]
+			}
+		}
+		w := gjkSupport(a, b, &best.n)
+		if w.w.Dot(&best.n)-best.d <= GjkTolerance*math.Max(1, best.d) {
+			break
+		}
+		verts = append(verts, w)
+		wi := len(verts) - 1
+		var edges [][2]int
+		kept := faces[:0:0]
+		for _, f := range faces {
+			if f.n.DotSub(&w.w, &verts[f.i[0]].w) > 0 {
+				for e := 0; e < 3; e++ {
+					edge := [2]int{f.i[e], f.i[(e+1)%3]}
+					shared := false
+					for k := range edges {
+						if edges[k][0] == edge[1] && edges[k][1] == edge[0] {
+							edges, shared = append(edges[:k], edges[k+1:]...), true
+							break
+						}
+					}
+					if !shared {
+						edges = append(edges, edge)
+					}
+				}
+			} else {
+				kept = append(kept, f)
+			}
+		}
+		if len(edges) == 0 {
+			break
+		}
+		for _, e := range edges {
+			kept = append(kept, epaMakeFace(verts, e[0], e[1], wi))
+		}
+		faces = kept
+	}
+	depth, normal = best.d, &Vec3{best.n.X, best.n.Y, best.n.Z}
+	u, v, w := barycentric(normal.Scaled(depth), &verts[best.i[0]].w, &verts[best.i[1]].w, &verts[best.i[2]].w)
+	pa, pb = new(Vec3), new(Vec3)
+	for k, l := range [3]float64{u, v, w} {
+		pa.SetFromAddScaled(pa, &verts[best.i[k]].a, l)
+		pb.SetFromAddScaled(pb, &verts[best.i[k]].b, l)
+	}
+	ok = true
+	return
+}
+
+func epaMakeFace(verts []gjkVertex, i0, i1, i2 int) (f epaFace) {
+	var ab, ac Vec3
+	ab.SetFromSub(&verts[i1].w, &verts[i0].w)
+	ac.SetFromSub(&verts[i2].w, &verts[i0].w)
+	f.i = [3]int{i0, i1, i2}
+	f.n.SetFromCrossOf(&ab, &ac)
+	f.n.NormalizeSafe()
+	f.d = f.n.Dot(&verts[i0].w)
+	return
+}
+
+//	Grows `simplex` (as left by GJK when the shapes overlap) into a non-degenerate tetrahedron.
+func epaTetrahedron(a, b ConvexShape, simplex *gjkSimplex) bool {
+	var d, e Vec3
+	tol := GjkTolerance * GjkTolerance
+	add := func(v gjkVertex) {
+		simplex.v[simplex.n] = v
+		simplex.n++
+	}
+	if simplex.n == 1 {
+		for _, dir := range []Vec3{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
+			if v := gjkSupport(a, b, &dir); v.w.DistanceSq(&simplex.v[0].w) > tol {
+				add(v)
+				break
+			}
+		}
+	}
+	if simplex.n == 2 {
+		d.SetFromSub(&simplex.v[1].w, &simplex.v[0].w)
+		axis := Vec3{1, 0, 0}
+		if math.Abs(d.Y) < math.Abs(d.X) && math.Abs(d.Y) <= math.Abs(d.Z) {
+			axis = Vec3{0, 1, 0}
+		} else if math.Abs(d.Z) < math.Abs(d.X) {
+			axis = Vec3{0, 0, 1}
+		}
+		p1 := d.Cross(&axis)
+		p2 := d.Cross(p1)
+		for _, dir := range []*Vec3{p1, p1.Negated(), p2, p2.Negated()} {
+			v := gjkSupport(a, b, dir)
+			e.SetFromSub(&v.w, &simplex.v[0].w)
+			if d.Cross(&e).Length() > tol*d.Length() {
+				add(v)
+				break
+			}
+		}
+	}
+	if simplex.n == 3 {
+		d.SetFromSub(&simplex.v[1].w, &simplex.v[0].w)
+		e.SetFromSub(&simplex.v[2].w, &simplex.v[0].w)
+		n := d.Cross(&e)
+		for _, dir := range []*Vec3{n, n.Negated()} {
+			if v := gjkSupport(a, b, dir); math.Abs(n.DotSub(&v.w, &simplex.v[0].w)) > tol*n.Magnitude() {
+				add(v)
+				break
+			}
+		}
+	}
+	return simplex.n == 4
+}
+
+//	Returns the barycentric coordinates of `p` with respect to the triangle `a`, `b`, `c`, such that `p = u*a + v*b + w*c`.
+func barycentric(p, a, b, c *Vec3) (u, v, w float64) {
+	var v0, v1, v2 Vec3
+	v0.SetFromSub(b, a)
+	v1.SetFromSub(c, a)
+	v2.SetFromSub(p, a)
+	d00, d01, d11 := v0.Dot(&v0), v0.Dot(&v1), v1.Dot(&v1)
+	d20, d21 := v2.Dot(&v0), v2.Dot(&v1)
+	if denom := d00*d11 - d01*d01; denom != 0 {
+		v = (d11*d20 - d01*d21) / denom
+		w = (d00*d21 - d01*d20) / denom
+	}
+	u = 1 - v - w
+	return
+}
@@ -0,0 +1,543 @@
+package unum
+
+//	One directed half of an edge in a `HalfEdgeMesh`. The two halves of an edge are stored next to each other,
+//	so that the twin (opposite half-edge) of half-edge `h` is always `h ^ 1`.
+type HalfEdge struct {
+	//	The vertex this half-edge points to, or -1 if the half-edge was deleted.
+	To int
+
+	//	The face to the left of this half-edge, or -1 if the half-edge lies on a boundary.
+	Face int
+
+	//	The next and previous half-edges around `Face`, or around the boundary loop for boundary half-edges.
+	Next, Prev int
+}
+
+//	Represents a polygon mesh as a half-edge structure, for topology queries and local editing. Faces are
+//	counter-clockwise polygons (often triangles, though see `NewHalfEdgeMeshPolygons` and `ExtrudeFace`), and every
+//	hole is bordered by a loop of boundary half-edges (with `Face` -1), so that every half-edge has a twin.
+//
+//	Editing operations delete elements by marking them (see `HalfEdge.To`, `VertexOut` and `FaceEdge`)
+//	rather than renumbering; call `Compact` to remove them.
+type HalfEdgeMesh struct {
+	//	Vertex positions.
+	Positions []Vec3
+
+	//	All half-edges, twins in adjacent pairs.
+	HalfEdges []HalfEdge
+
+	//	For each vertex, an outgoing half-edge (a boundary one for boundary vertices), or -1 if the vertex is
+	//	isolated or deleted.
+	VertexOut []int
+
+	//	For each face, one of its half-edges, or -1 if the face was deleted.
+	FaceEdge []int
+}
+
+//	Returns a new `*HalfEdgeMesh` from the specified triangles, where every 3 consecutive `indices` index the
+//	`positions` (which are copied) of one counter-clockwise triangle. Returns `ok` of `false` if the triangles
+//	cannot be represented (see `NewHalfEdgeMeshPolygons`).
+func NewHalfEdgeMesh(positions []Vec3, indices []uint32) (me *HalfEdgeMesh, ok bool) {
+	faces := make([][]int, len(indices)/3)
+	for f := range faces {
+		faces[f] = []int{int(indices[3*f]), int(indices[3*f+1]), int(indices[3*f+2])}
+	}
+	return NewHalfEdgeMeshPolygons(positions, faces)
+}
+
+//	Returns a new `*HalfEdgeMesh` from the specified counter-clockwise polygon `faces`, each listing indices into
+//	`positions` (which are copied). Returns `ok` of `false` if the faces cannot be represented: if a face has fewer
+//	than 3 sides or repeats a vertex, or an edge is shared by more than 2 faces or by 2 faces of opposite orientation.
+func NewHalfEdgeMeshPolygons(positions []Vec3, faces [][]int) (me *HalfEdgeMesh, ok bool) {
+	me = &HalfEdgeMesh{Positions: append([]Vec3(nil), positions...), VertexOut: make([]int, len(positions))}
+	for v := range me.VertexOut {
+		me.VertexOut[v] = -1
+	}
+	edges := map[[2]int]int{}
+	for f, face := range faces {
+		if len(face) < 3 {
+			return nil, false
+		}
+		for k := range face {
+			for _, other := range face[k+1:] {
+				if face[k] == other {
+					return nil, false
+				}
+			}
+		}
+		hs := make([]int, len(face))
+		for k, from := range face {
+			to := face[(k+1)%len(face)]
+			if _, dup := edges[[2]int{from, to}]; dup {
+				return nil, false
+			}
+			if twin, exists := edges[[2]int{to, from}]; exists {
+				hs[k] = twin ^ 1
+			} else {
+				hs[k] = me.newEdge(from, to)
+			}
+			edges[[2]int{from, to}] = hs[k]
+			me.VertexOut[from] = hs[k]
+		}
+		me.FaceEdge = append(me.FaceEdge, hs[0])
+		me.linkFace(f, hs...)
+	}
+	//	link each boundary half-edge to the boundary half-edge leaving its end, found by rotating around that vertex
+	for b := range me.HalfEdges {
+		if me.HalfEdges[b].Face < 0 {
+			for e := b ^ 1; ; {
+				if p := me.HalfEdges[e].Prev ^ 1; me.HalfEdges[p].Face < 0 {
+					me.link(b, p)
+					break
+				} else {
+					e = p
+				}
+			}
+			me.VertexOut[me.From(b)] = b
+		}
+	}
+	return me, true
+}
+
+//	Returns the boundary loops of `me`, each as its vertices in order along its boundary half-edges.
+//	A closed mesh has none.
+func (me *HalfEdgeMesh) BoundaryLoops() (loops [][]int) {
+	seen := make([]bool, len(me.HalfEdges))
+	for b, he := range me.HalfEdges {
+		if he.To >= 0 && he.Face < 0 && !seen[b] {
+			var loop []int
+			for h := b; !seen[h]; h = me.HalfEdges[h].Next {
+				seen[h] = true
+				loop = append(loop, me.From(h))
+			}
+			loops = append(loops, loop)
+		}
+	}
+	return
+}
+
+//	Returns whether the edge of half-edge `h` may be collapsed by `CollapseEdge` without making the mesh
+//	non-manifold or degenerate: `h` must border only triangles, the vertices at its ends must share no neighbors
+//	other than the opposite corners of those triangles, and it must not join two boundaries through the interior.
+func (me *HalfEdgeMesh) CanCollapse(h int) bool {
+	a, b := me.From(h), me.HalfEdges[h].To
+	if b < 0 {
+		return false
+	}
+	opposite := map[int]bool{}
+	for _, s := range []int{h, h ^ 1} {
+		if f := me.HalfEdges[s].Face; f >= 0 {
+			if me.faceDegree(f) != 3 {
+				return false
+			}
+			c := me.HalfEdges[me.HalfEdges[s].Next].To
+			//	collapsing must not leave c with a dangling edge or a doubled triangle
+			if valence := me.Valence(c); valence <= 2 || (valence == 3 && !me.IsBoundaryVertex(c)) {
+				return false
+			}
+			opposite[c] = true
+		}
+	}
+	if me.IsBoundaryVertex(a) && me.IsBoundaryVertex(b) && !me.IsBoundaryEdge(h) {
+		return false
+	}
+	ring := map[int]bool{}
+	for _, v := range me.OneRing(a) {
+		ring[v] = true
+	}
+	for _, v := range me.OneRing(b) {
+		if ring[v] && !opposite[v] {
+			return false
+		}
+	}
+	return true
+}
+
+//	Collapses the edge of half-edge `h` by merging its start vertex into its end vertex (which keeps its index and
+//	is moved to `pos`), deleting the start vertex, the edge and the 1 or 2 triangles beside it.
+//	Returns `false` and does nothing if `CanCollapse` returns `false`.
+func (me *HalfEdgeMesh) CollapseEdge(h int, pos *Vec3) bool {
+	if !me.CanCollapse(h) {
+		return false
+	}
+	a, b, t := me.From(h), me.HalfEdges[h].To, h^1
+	for _, o := range me.outgoing(a) {
+		me.HalfEdges[o^1].To = b
+	}
+	var fix []int
+	for _, s := range []int{h, t} {
+		hs := &me.HalfEdges[s]
+		if hs.Face < 0 {
+			me.link(hs.Prev, hs.Next)
+			fix = append(fix, hs.Next)
+			continue
+		}
+		//	the triangle's other 2 edges become 1: `n` takes over the place of `p`'s twin
+		n, p := hs.Next, hs.Prev
+		me.FaceEdge[hs.Face] = -1
+		po := me.HalfEdges[p^1]
+		me.HalfEdges[n].Face = po.Face
+		me.link(po.Prev, n)
+		me.link(n, po.Next)
+		if po.Face >= 0 && me.FaceEdge[po.Face] == p^1 {
+			me.FaceEdge[po.Face] = n
+		}
+		me.deleteEdge(p)
+		fix = append(fix, n, n^1)
+	}
+	me.deleteEdge(h)
+	me.Positions[b], me.VertexOut[a] = *pos, -1
+	for _, o := range fix {
+		if me.HalfEdges[o].To >= 0 {
+			me.fixVertexOut(me.From(o), o)
+		}
+	}
+	return true
+}
+
+//	Removes all deleted vertices, half-edges and faces, renumbering the remaining ones in their existing order.
+//	Isolated vertices are removed, too.
+func (me *HalfEdgeMesh) Compact() {
+	vmap, emap, fmap := make([]int, len(me.Positions)), make([]int, len(me.HalfEdges)), make([]int, len(me.FaceEdge))
+	var positions []Vec3
+	var vout []int
+	for v := range me.Positions {
+		if vmap[v] = -1; me.VertexOut[v] >= 0 {
+			vmap[v] = len(positions)
+			positions, vout = append(positions, me.Positions[v]), append(vout, me.VertexOut[v])
+		}
+	}
+	num := 0
+	for h := range me.HalfEdges {
+		if emap[h] = -1; me.HalfEdges[h].To >= 0 {
+			emap[h], num = num, num+1
+		}
+	}
+	var faces []int
+	for f, h := range me.FaceEdge {
+		if fmap[f] = -1; h >= 0 {
+			fmap[f] = len(faces)
+			faces = append(faces, emap[h])
+		}
+	}
+	edges := make([]HalfEdge, 0, num)
+	for _, he := range me.HalfEdges {
+		if he.To >= 0 {
+			if he.Face >= 0 {
+				he.Face = fmap[he.Face]
+			}
+			he.To, he.Next, he.Prev = vmap[he.To], emap[he.Next], emap[he.Prev]
+			edges = append(edges, he)
+		}
+	}
+	for v := range vout {
+		vout[v] = emap[vout[v]]
+	}
+	me.Positions, me.HalfEdges, me.VertexOut, me.FaceEdge = positions, edges, vout, faces
+}
+
+//	Extrudes face `f` by `offset`: its vertices are duplicated and moved by `offset` to form the new face `f`,
+//	and each of its original edges is connected to its duplicate by a new quad face. Returns the new vertices,
+//	in order around `f`.
+func (me *HalfEdgeMesh) ExtrudeFace(f int, offset *Vec3) (verts []int) {
+	loop := me.faceEdges(f)
+	n := len(loop)
+	ups, caps := make([]int, n), make([]int, n)
+	for i, h := range loop {
+		v := me.From(h)
+		verts = append(verts, len(me.Positions))
+		me.Positions = append(me.Positions, *me.Positions[v].Added(offset))
+		me.VertexOut = append(me.VertexOut, -1)
+		ups[i] = me.newEdge(v, verts[i])
+	}
+	for i := range loop {
+		caps[i] = me.newEdge(verts[i], verts[(i+1)%n])
+		me.VertexOut[verts[i]] = caps[i]
+	}
+	me.FaceEdge[f] = caps[0]
+	me.linkFace(f, caps...)
+	for i, h := range loop {
+		side := len(me.FaceEdge)
+		me.FaceEdge = append(me.FaceEdge, h)
+		me.linkFace(side, h, ups[(i+1)%n], caps[i]^1, ups[i]^1)
+	}
+	return
+}
+
+//	Returns the unit normal of face `f`, using Newell's method so that non-planar polygons are handled.
+func (me *HalfEdgeMesh) FaceNormal(f int) (n *Vec3) {
+	n = &Vec3{}
+	for _, h := range me.faceEdges(f) {
+		p, q := &me.Positions[me.From(h)], &me.Positions[me.HalfEdges[h].To]
+		n.Add3((p.Y-q.Y)*(p.Z+q.Z), (p.Z-q.Z)*(p.X+q.X), (p.X-q.X)*(p.Y+q.Y))
+	}
+	n.NormalizeSafe()
+	return
+}
+
+//	Returns the vertices of face `f`, counter-clockwise.
+func (me *HalfEdgeMesh) FaceVertices(f int) (verts []int) {
+	for _, h := range me.faceEdges(f) {
+		verts = append(verts, me.From(h))
+	}
+	return
+}
+
+//	Flips the edge of half-edge `h`, replacing the 2 triangles beside it with the 2 triangles formed by the other
+//	diagonal of the quad they form. Returns `false` and does nothing if `h` borders a boundary or non-triangle,
+//	or if the other diagonal already exists as an edge.
+func (me *HalfEdgeMesh) FlipEdge(h int) bool {
+	t := h ^ 1
+	f1, f2 := me.HalfEdges[h].Face, me.HalfEdges[t].Face
+	if f1 < 0 || f2 < 0 || me.faceDegree(f1) != 3 || me.faceDegree(f2) != 3 {
+		return false
+	}
+	//	h: a->b in (a, b, c), t: b->a in (b, a, d), becoming h: d->c in (a, d, c) and t: c->d in (d, b, c)
+	bc, ca, ad, db := me.HalfEdges[h].Next, me.HalfEdges[h].Prev, me.HalfEdges[t].Next, me.HalfEdges[t].Prev
+	a, b, c, d := me.From(h), me.HalfEdges[h].To, me.HalfEdges[bc].To, me.HalfEdges[ad].To
+	for _, v := range me.OneRing(c) {
+		if v == d {
+			return false
+		}
+	}
+	me.HalfEdges[h].To, me.HalfEdges[t].To = c, d
+	me.FaceEdge[f1], me.FaceEdge[f2] = h, t
+	me.linkFace(f1, ad, h, ca)
+	me.linkFace(f2, db, bc, t)
+	if me.VertexOut[a] == h {
+		me.VertexOut[a] = ad
+	}
+	if me.VertexOut[b] == t {
+		me.VertexOut[b] = bc
+	}
+	return true
+}
+
+//	Returns the vertex half-edge `h` starts from.
+func (me *HalfEdgeMesh) From(h int) int {
+	return me.HalfEdges[h^1].To
+}
+
+//	Returns whether half-edge `h` or its twin lies on a boundary.
+func (me *HalfEdgeMesh) IsBoundaryEdge(h int) bool {
+	return me.HalfEdges[h].Face < 0 || me.HalfEdges[h^1].Face < 0
+}
+
+//	Returns whether vertex `v` lies on a boundary.
+func (me *HalfEdgeMesh) IsBoundaryVertex(v int) bool {
+	return me.VertexOut[v] >= 0 && me.HalfEdges[me.VertexOut[v]].Face < 0
+}
+
+//	Returns whether the structure of `me` is consistent and describes a manifold: all links are mutual, every face
+//	has at least 3 sides, and the faces around every vertex form a single fan (so that, for example, no two
+//	cones touch only at their apex).
+func (me *HalfEdgeMesh) IsManifold() bool {
+	counts := make([]int, len(me.Positions))
+	for h, he := range me.HalfEdges {
+		if he.To < 0 {
+			continue
+		}
+		next := &me.HalfEdges[he.Next]
+		if next.To < 0 || me.HalfEdges[next.Prev].To < 0 || next.Prev != h || next.Face != he.Face || me.From(he.Next) != he.To || me.HalfEdges[h^1].To < 0 {
+			return false
+		}
+		counts[me.From(h)]++
+	}
+	for f, h := range me.FaceEdge {
+		if h >= 0 && (me.HalfEdges[h].Face != f || me.faceDegree(f) < 3) {
+			return false
+		}
+	}
+	for v, h := range me.VertexOut {
+		if h < 0 {
+			if counts[v] > 0 {
+				return false
+			}
+			continue
+		}
+		out, boundaries := me.outgoing(v), 0
+		for _, o := range out {
+			if me.HalfEdges[o].Face < 0 {
+				boundaries++
+			}
+		}
+		if len(out) != counts[v] || boundaries > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns the neighbors of vertex `v` in counter-clockwise order, starting (for boundary vertices) with the
+//	neighbor along the boundary half-edge arriving at `v`, and ending with the one along the boundary half-edge leaving it.
+func (me *HalfEdgeMesh) OneRing(v int) (verts []int) {
+	for _, h := range me.outgoing(v) {
+		verts = append(verts, me.HalfEdges[h].To)
+	}
+	return
+}
+
+//	Splits the edge of half-edge `h` at a new vertex at `pos`, also splitting the triangles beside it in 2 each
+//	by connecting the new vertex to their opposite corners. Returns the new vertex.
+func (me *HalfEdgeMesh) SplitEdge(h int, pos *Vec3) (v int) {
+	t, b := h^1, me.HalfEdges[h].To
+	v = len(me.Positions)
+	me.Positions, me.VertexOut = append(me.Positions, *pos), append(me.VertexOut, -1)
+	//	h: a->b becomes h: a->v, n: v->b, and t: b->a becomes n^1: b->v, t: v->a
+	n := me.newEdge(v, b)
+	hNext, tPrev := me.HalfEdges[h].Next, me.HalfEdges[t].Prev
+	me.HalfEdges[h].To = v
+	me.HalfEdges[n].Face, me.HalfEdges[n^1].Face = me.HalfEdges[h].Face, me.HalfEdges[t].Face
+	me.link(h, n)
+	me.link(n, hNext)
+	me.link(tPrev, n^1)
+	me.link(n^1, t)
+	if me.VertexOut[b] == t {
+		me.VertexOut[b] = n ^ 1
+	}
+	if me.VertexOut[v] = n; me.HalfEdges[t].Face < 0 {
+		me.VertexOut[v] = t
+	}
+	if me.HalfEdges[h].Face >= 0 && me.faceDegree(me.HalfEdges[h].Face) == 4 {
+		me.connect(h, hNext)
+	}
+	if me.HalfEdges[t].Face >= 0 && me.faceDegree(me.HalfEdges[t].Face) == 4 {
+		me.connect(n^1, me.HalfEdges[t].Next)
+	}
+	return
+}
+
+//	Returns the triangle mesh of `me` (see `Triangles`) as a `*Mesh` with only `Positions`.
+func (me *HalfEdgeMesh) ToMesh() *Mesh {
+	positions, indices := me.Triangles()
+	return &Mesh{Positions: positions, Indices: indices}
+}
+
+//	Returns the positions of all vertices that are not deleted or isolated, and every 3 consecutive `indices` into
+//	them forming one counter-clockwise triangle. Faces with more than 3 sides are triangulated as fans.
+func (me *HalfEdgeMesh) Triangles() (positions []Vec3, indices []uint32) {
+	remap := make([]int, len(me.Positions))
+	for v := range me.Positions {
+		if remap[v] = -1; me.VertexOut[v] >= 0 {
+			remap[v] = len(positions)
+			positions = append(positions, me.Positions[v])
+		}
+	}
+	for f, h := range me.FaceEdge {
+		if h >= 0 {
+			verts := me.FaceVertices(f)
+			for i := 2; i < len(verts); i++ {
+				indices = append(indices, uint32(remap[verts[0]]), uint32(remap[verts[i-1]]), uint32(remap[verts[i]]))
+			}
+		}
+	}
+	return
+}
+
+//	Returns the number of edges at vertex `v`.
+func (me *HalfEdgeMesh) Valence(v int) int {
+	return len(me.outgoing(v))
+}
+
+//	Returns the faces around vertex `v`, in counter-clockwise order.
+func (me *HalfEdgeMesh) VertexFaces(v int) (faces []int) {
+	for _, h := range me.outgoing(v) {
+		if f := me.HalfEdges[h].Face; f >= 0 {
+			faces = append(faces, f)
+		}
+	}
+	return
+}
+
+//	Inserts a new edge across the face of half-edges `hu` and `hv`, from the end of `hu` to the end of `hv`.
+//	The face keeps the part from `hu` to the new edge, and the rest becomes a new face.
+func (me *HalfEdgeMesh) connect(hu, hv int) (e int) {
+	f := me.HalfEdges[hu].Face
+	uNext, vNext := me.HalfEdges[hu].Next, me.HalfEdges[hv].Next
+	e = me.newEdge(me.HalfEdges[hu].To, me.HalfEdges[hv].To)
+	me.link(hu, e)
+	me.link(e, vNext)
+	me.link(hv, e^1)
+	me.link(e^1, uNext)
+	me.FaceEdge[f] = e
+	g := len(me.FaceEdge)
+	me.FaceEdge = append(me.FaceEdge, e^1)
+	for h := e ^ 1; ; h = me.HalfEdges[h].Next {
+		if me.HalfEdges[h].Face = g; me.HalfEdges[h].Next == e^1 {
+			break
+		}
+	}
+	me.HalfEdges[e].Face = f
+	return
+}
+
+func (me *HalfEdgeMesh) deleteEdge(h int) {
+	me.HalfEdges[h].To, me.HalfEdges[h^1].To = -1, -1
+}
+
+func (me *HalfEdgeMesh) faceDegree(f int) int {
+	return len(me.faceEdges(f))
+}
+
+func (me *HalfEdgeMesh) faceEdges(f int) (edges []int) {
+	for h := me.FaceEdge[f]; ; {
+		if edges = append(edges, h); me.HalfEdges[h].Next == me.FaceEdge[f] {
+			return
+		}
+		h = me.HalfEdges[h].Next
+	}
+}
+
+//	Sets `VertexOut[v]` to its boundary outgoing half-edge, if any, or else to `start`, an outgoing half-edge of `v`.
+func (me *HalfEdgeMesh) fixVertexOut(v, start int) {
+	me.VertexOut[v] = start
+	for h := start; ; {
+		if me.HalfEdges[h].Face < 0 {
+			me.VertexOut[v] = h
+			return
+		}
+		if h = me.HalfEdges[h].Prev ^ 1; h == start {
+			return
+		}
+	}
+}
+
+func (me *HalfEdgeMesh) link(h, next int) {
+	me.HalfEdges[h].Next, me.HalfEdges[next].Prev = next, h
+}
+
+//	Links `edges` into a cycle around face `f`.
+func (me *HalfEdgeMesh) linkFace(f int, edges ...int) {
+	for i, h := range edges {
+		me.HalfEdges[h].Face = f
+		me.link(h, edges[(i+1)%len(edges)])
+	}
+}
+
+//	Appends a new twin pair of unlinked boundary half-edges from `from` to `to` and back, returning the first.
+func (me *HalfEdgeMesh) newEdge(from, to int) (h int) {
+	h = len(me.HalfEdges)
+	me.HalfEdges = append(me.HalfEdges, HalfEdge{To: to, Face: -1}, HalfEdge{To: from, Face: -1})
+	return
+}
+
+//	Returns the outgoing half-edges of vertex `v` in counter-clockwise order: for boundary vertices, from the
+//	twin of the boundary half-edge arriving at `v` to the boundary half-edge leaving it.
+func (me *HalfEdgeMesh) outgoing(v int) (out []int) {
+	start := me.VertexOut[v]
+	if start < 0 {
+		return
+	}
+	if me.HalfEdges[start].Face < 0 {
+		start = me.HalfEdges[start].Prev ^ 1
+	}
+	for h := start; ; {
+		if out = append(out, h); len(out) > len(me.HalfEdges) {
+			//	corrupt links: bail out rather than loop forever
+			return
+		}
+		if h = me.HalfEdges[h].Prev ^ 1; h == start {
+			return
+		}
+	}
+}
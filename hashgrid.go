@@ -0,0 +1,241 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents an unbounded uniform grid over `Vec2` points, hashing each occupied cell by its integer coordinates
+//	(the floor of the point's coordinates divided by the cell size). Best suited to many similarly-sized items
+//	with a cell size near their query radius. A `HashGrid2` is not safe for concurrent modification.
+type HashGrid2 struct {
+	grid hashGrid
+}
+
+//	Returns a new `*HashGrid2` with the specified `cellSize`.
+func NewHashGrid2(cellSize float64) (me *HashGrid2) {
+	me = new(HashGrid2)
+	me.grid.init(2, cellSize)
+	return
+}
+
+//	Adds an item at `pos` with the associated `item` (which may be `nil`) and returns its ID.
+func (me *HashGrid2) Insert(pos *Vec2, item interface{}) int {
+	return me.grid.insert([3]float64{pos.X, pos.Y}, item)
+}
+
+//	Returns the item associated with the specified `id`.
+func (me *HashGrid2) Item(id int) interface{} {
+	return me.grid.items[id].item
+}
+
+//	Returns the position of the item with the specified `id`.
+func (me *HashGrid2) Position(id int) *Vec2 {
+	p := &me.grid.items[id].pos
+	return &Vec2{p[0], p[1]}
+}
+
+//	Calls `visit` with the ID of every item within `radius` of `center`, until `visit` returns `false`.
+func (me *HashGrid2) QueryRadius(center *Vec2, radius float64, visit func(id int) bool) {
+	me.grid.queryRadius([3]float64{center.X, center.Y}, radius, visit)
+}
+
+//	Calls `visit` with the ID of every item within the axis-aligned rectangle from `min` to `max`,
+//	until `visit` returns `false`.
+func (me *HashGrid2) QueryRect(min, max *Vec2, visit func(id int) bool) {
+	me.grid.queryBox([3]float64{min.X, min.Y}, [3]float64{max.X, max.Y}, visit)
+}
+
+//	Removes the item with the specified `id`, which may then be reused by `Insert`.
+func (me *HashGrid2) Remove(id int) {
+	me.grid.remove(id)
+}
+
+//	Moves the item with the specified `id` to `pos`.
+func (me *HashGrid2) Update(id int, pos *Vec2) {
+	me.grid.update(id, [3]float64{pos.X, pos.Y})
+}
+
+//	Represents an unbounded uniform grid over `Vec3` points: the 3D counterpart of `HashGrid2`.
+type HashGrid3 struct {
+	grid hashGrid
+}
+
+//	Returns a new `*HashGrid3` with the specified `cellSize`.
+func NewHashGrid3(cellSize float64) (me *HashGrid3) {
+	me = new(HashGrid3)
+	me.grid.init(3, cellSize)
+	return
+}
+
+//	Adds an item at `pos` with the associated `item` (which may be `nil`) and returns its ID.
+func (me *HashGrid3) Insert(pos *Vec3, item interface{}) int {
+	return me.grid.insert([3]float64{pos.X, pos.Y, pos.Z}, item)
+}
+
+//	Returns the item associated with the specified `id`.
+func (me *HashGrid3) Item(id int) interface{} {
+	return me.grid.items[id].item
+}
+
+//	Returns the position of the item with the specified `id`.
+func (me *HashGrid3) Position(id int) *Vec3 {
+	p := &me.grid.items[id].pos
+	return &Vec3{p[0], p[1], p[2]}
+}
+
+//	Calls `visit` with the ID of every item within `aabb`, until `visit` returns `false`.
+func (me *HashGrid3) QueryAABB(aabb *AABB, visit func(id int) bool) {
+	me.grid.queryBox([3]float64{aabb.Min.X, aabb.Min.Y, aabb.Min.Z}, [3]float64{aabb.Max.X, aabb.Max.Y, aabb.Max.Z}, visit)
+}
+
+//	Calls `visit` with the ID of every item within `radius` of `center`, until `visit` returns `false`.
+func (me *HashGrid3) QueryRadius(center *Vec3, radius float64, visit func(id int) bool) {
+	me.grid.queryRadius([3]float64{center.X, center.Y, center.Z}, radius, visit)
+}
+
+//	Removes the item with the specified `id`, which may then be reused by `Insert`.
+func (me *HashGrid3) Remove(id int) {
+	me.grid.remove(id)
+}
+
+//	Moves the item with the specified `id` to `pos`.
+func (me *HashGrid3) Update(id int, pos *Vec3) {
+	me.grid.update(id, [3]float64{pos.X, pos.Y, pos.Z})
+}
+
+//	The dimension-agnostic implementation of `HashGrid2` and `HashGrid3`.
+type hashGrid struct {
+	dims    int
+	invSize float64
+	cells   map[[3]int64][]int
+	items   []hashGridItem
+	free    []int
+}
+
+type hashGridItem struct {
+	pos  [3]float64
+	cell [3]int64
+	item interface{}
+}
+
+func (me *hashGrid) init(dims int, cellSize float64) {
+	me.dims, me.invSize = dims, 1/cellSize
+	me.cells = map[[3]int64][]int{}
+}
+
+//	Returns the integer coordinates of the cell containing `pos`.
+func (me *hashGrid) cellOf(pos *[3]float64) (cell [3]int64) {
+	for a := 0; a < me.dims; a++ {
+		cell[a] = int64(math.Floor(pos[a] * me.invSize))
+	}
+	return
+}
+
+func (me *hashGrid) insert(pos [3]float64, item interface{}) (id int) {
+	if l := len(me.free); l > 0 {
+		id, me.free = me.free[l-1], me.free[:l-1]
+	} else {
+		id = len(me.items)
+		me.items = append(me.items, hashGridItem{})
+	}
+	me.items[id] = hashGridItem{pos: pos, cell: me.cellOf(&pos), item: item}
+	me.link(id)
+	return
+}
+
+func (me *hashGrid) link(id int) {
+	cell := me.items[id].cell
+	me.cells[cell] = append(me.cells[cell], id)
+}
+
+func (me *hashGrid) unlink(id int) {
+	cell := me.items[id].cell
+	ids := me.cells[cell]
+	for i, other := range ids {
+		if other == id {
+			last := len(ids) - 1
+			ids[i], ids = ids[last], ids[:last]
+			break
+		}
+	}
+	if len(ids) == 0 {
+		delete(me.cells, cell)
+	} else {
+		me.cells[cell] = ids
+	}
+}
+
+func (me *hashGrid) remove(id int) {
+	me.unlink(id)
+	me.items[id] = hashGridItem{}
+	me.free = append(me.free, id)
+}
+
+func (me *hashGrid) update(id int, pos [3]float64) {
+	it := &me.items[id]
+	it.pos = pos
+	if cell := me.cellOf(&pos); cell != it.cell {
+		me.unlink(id)
+		it.cell = cell
+		me.link(id)
+	}
+}
+
+//	Visits all items passing `test` in all cells overlapping the box from `min` to `max`.
+func (me *hashGrid) query(min, max [3]float64, test func(*hashGridItem) bool, visit func(id int) bool) {
+	lo, hi := me.cellOf(&min), me.cellOf(&max)
+	if span := float64(hi[0]-lo[0]+1) * float64(hi[1]-lo[1]+1) * float64(hi[2]-lo[2]+1); span > float64(len(me.cells)) {
+		//	the box spans more cells than are occupied: scan the occupied ones instead
+		for cell, ids := range me.cells {
+			inside := true
+			for a := 0; a < me.dims && inside; a++ {
+				inside = cell[a] >= lo[a] && cell[a] <= hi[a]
+			}
+			for i := 0; inside && i < len(ids); i++ {
+				if test(&me.items[ids[i]]) && !visit(ids[i]) {
+					return
+				}
+			}
+		}
+		return
+	}
+	var cell [3]int64
+	for cell[2] = lo[2]; cell[2] <= hi[2]; cell[2]++ {
+		for cell[1] = lo[1]; cell[1] <= hi[1]; cell[1]++ {
+			for cell[0] = lo[0]; cell[0] <= hi[0]; cell[0]++ {
+				for _, id := range me.cells[cell] {
+					if test(&me.items[id]) && !visit(id) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func (me *hashGrid) queryBox(min, max [3]float64, visit func(id int) bool) {
+	me.query(min, max, func(it *hashGridItem) bool {
+		for a := 0; a < me.dims; a++ {
+			if it.pos[a] < min[a] || it.pos[a] > max[a] {
+				return false
+			}
+		}
+		return true
+	}, visit)
+}
+
+func (me *hashGrid) queryRadius(center [3]float64, radius float64, visit func(id int) bool) {
+	var min, max [3]float64
+	for a := 0; a < me.dims; a++ {
+		min[a], max[a] = center[a]-radius, center[a]+radius
+	}
+	r2 := radius * radius
+	me.query(min, max, func(it *hashGridItem) bool {
+		sq := 0.0
+		for a := 0; a < me.dims; a++ {
+			d := it.pos[a] - center[a]
+			sq += d * d
+		}
+		return sq <= r2
+	}, visit)
+}
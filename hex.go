@@ -0,0 +1,271 @@
+package unum
+
+import (
+	"math"
+)
+
+//	The 6 axial directions, counter-clockwise starting with "east" (`+Q`) for `Hex.Neighbor` and `Hex.Ring`.
+//
+//	Throughout, counter-clockwise (for directions, rings, rotations and corners alike) is meant in the Y-up frame
+//	that `HexLayout.ToPixel` maps to, as for `Polygon.IsCCW`: `+R` lies above `+Q`. On a Y-down screen, the same
+//	orders appear clockwise.
+var HexDirections = [6]Hex{{1, 0}, {0, 1}, {-1, 1}, {-1, 0}, {0, -1}, {1, -1}}
+
+//	Represents the axial coordinates of a cell in a hexagonal grid. The third cube coordinate is implied as `-Q-R`.
+//	Being a comparable value type, a `Hex` is suitable as a map key.
+type Hex struct{ Q, R int }
+
+//	Represents the cube coordinates of a cell in a hexagonal grid, with `Q + R + S == 0`.
+type HexCube struct{ Q, R, S int }
+
+//	Represents the column and row of a cell in a hexagonal grid laid out as a rectangle,
+//	where every other row (or column) is shoved over according to a `HexOffsetKind`.
+type HexOffset struct{ Col, Row int }
+
+//	Specifies which rows (for pointy layouts) or columns (for flat layouts) of a `HexOffset` grid are shoved over by half a cell.
+type HexOffsetKind int
+
+const (
+	//	Pointy layout with the odd rows shoved right.
+	HexOddR HexOffsetKind = iota
+
+	//	Pointy layout with the even rows shoved right.
+	HexEvenR
+
+	//	Flat layout with the odd columns shoved towards +Y.
+	HexOddQ
+
+	//	Flat layout with the even columns shoved towards +Y.
+	HexEvenQ
+)
+
+//	Returns the `Hex` nearest to the fractional axial coordinates `(q, r)`, via cube rounding.
+func HexRound(q, r float64) Hex {
+	s := -q - r
+	rq, rr, rs := math.Floor(q+0.5), math.Floor(r+0.5), math.Floor(s+0.5)
+	//	re-derive the coordinate with the largest rounding error from the other 2
+	if dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s); dq > dr && dq > ds {
+		rq = -rr - rs
+	} else if dr > ds {
+		rr = -rq - rs
+	}
+	return Hex{int(rq), int(rr)}
+}
+
+//	Returns the sum of `me` and `hex`.
+func (me Hex) Add(hex Hex) Hex {
+	return Hex{me.Q + hex.Q, me.R + hex.R}
+}
+
+//	Returns the cube coordinates of `me`.
+func (me Hex) Cube() HexCube {
+	return HexCube{me.Q, me.R, -me.Q - me.R}
+}
+
+//	Returns the number of steps from `me` to `hex`.
+func (me Hex) Distance(hex Hex) int {
+	return me.Sub(hex).Length()
+}
+
+//	Returns all cells within `radius` of `me` that are visible from it, in `Spiral` order, where a cell is
+//	visible if a line drawn from `me` to it (nudged slightly to either side, so that lines running exactly
+//	along cell edges are not needlessly obstructed) passes through no cells for which `blocked` returns `true`.
+//	Blocking cells themselves may be visible.
+func (me Hex) FieldOfView(radius int, blocked func(hex Hex) bool) (visible []Hex) {
+	for _, hex := range me.Spiral(radius) {
+		for _, nudge := range []float64{1, -1} {
+			line, clear := me.line(hex, nudge), true
+			for i := 1; clear && i < len(line)-1; i++ {
+				clear = !blocked(line[i])
+			}
+			if clear {
+				visible = append(visible, hex)
+				break
+			}
+		}
+	}
+	return
+}
+
+//	Returns the number of steps from the origin to `me`.
+func (me Hex) Length() int {
+	return (absInt(me.Q) + absInt(me.R) + absInt(me.Q+me.R)) / 2
+}
+
+//	Returns the cells on the straight line from `me` to `hex` (both included), in order, each adjacent to the next.
+func (me Hex) Line(hex Hex) []Hex {
+	return me.line(hex, 1)
+}
+
+//	Samples the line from `me` to `hex` at every cell step, nudged off cell edges in the direction of `nudge`'s sign.
+func (me Hex) line(hex Hex, nudge float64) (line []Hex) {
+	n := me.Distance(hex)
+	line = make([]Hex, n+1)
+	eq, er := 1e-6*nudge, 2e-6*nudge
+	for i := 0; i <= n; i++ {
+		t := 0.0
+		if n > 0 {
+			t = float64(i) / float64(n)
+		}
+		line[i] = HexRound(float64(me.Q)+eq+(float64(hex.Q-me.Q))*t, float64(me.R)+er+(float64(hex.R-me.R))*t)
+	}
+	return
+}
+
+//	Returns the adjacent cell in the specified `HexDirections` index (modulo 6).
+func (me Hex) Neighbor(dir int) Hex {
+	return me.Add(HexDirections[((dir%6)+6)%6])
+}
+
+//	Returns all 6 adjacent cells, in `HexDirections` order.
+func (me Hex) Neighbors() (n [6]Hex) {
+	for i := range n {
+		n[i] = me.Add(HexDirections[i])
+	}
+	return
+}
+
+//	Returns the offset coordinates of `me` in a grid of the specified `kind`.
+func (me Hex) Offset(kind HexOffsetKind) HexOffset {
+	switch kind {
+	case HexOddR:
+		return HexOffset{me.Q + (me.R-(me.R&1))/2, me.R}
+	case HexEvenR:
+		return HexOffset{me.Q + (me.R+(me.R&1))/2, me.R}
+	case HexOddQ:
+		return HexOffset{me.Q, me.R + (me.Q-(me.Q&1))/2}
+	}
+	return HexOffset{me.Q, me.R + (me.Q+(me.Q&1))/2}
+}
+
+//	Returns the cells exactly `radius` steps from `me`, counter-clockwise starting with the one in
+//	direction 4 (that is, `me + radius * HexDirections[4]`). Returns just `me` if `radius` is 0.
+func (me Hex) Ring(radius int) (ring []Hex) {
+	if radius <= 0 {
+		if radius == 0 {
+			ring = []Hex{me}
+		}
+		return
+	}
+	ring = make([]Hex, 0, 6*radius)
+	hex := me.Add(HexDirections[4].Scale(radius))
+	for i := 0; i < 6; i++ {
+		for j := 0; j < radius; j++ {
+			ring = append(ring, hex)
+			hex = hex.Neighbor(i)
+		}
+	}
+	return
+}
+
+//	Returns `me` rotated counter-clockwise about `center` by `steps` multiples of 60 degrees
+//	(clockwise for negative `steps`).
+func (me Hex) RotateAround(center Hex, steps int) Hex {
+	hex := me.Sub(center)
+	for steps = ((steps % 6) + 6) % 6; steps > 0; steps-- {
+		hex = hex.RotateLeft()
+	}
+	return hex.Add(center)
+}
+
+//	Returns `me` rotated counter-clockwise about the origin by 60 degrees.
+func (me Hex) RotateLeft() Hex {
+	return Hex{-me.R, me.Q + me.R}
+}
+
+//	Returns `me` rotated clockwise about the origin by 60 degrees.
+func (me Hex) RotateRight() Hex {
+	return Hex{me.Q + me.R, -me.Q}
+}
+
+//	Returns `me` with both coordinates multiplied by `factor`.
+func (me Hex) Scale(factor int) Hex {
+	return Hex{me.Q * factor, me.R * factor}
+}
+
+//	Returns all cells within `radius` of `me`: `me` first, then each `Ring` outwards.
+func (me Hex) Spiral(radius int) (cells []Hex) {
+	cells = make([]Hex, 0, 1+3*radius*(radius+1))
+	for r := 0; r <= radius; r++ {
+		cells = append(cells, me.Ring(r)...)
+	}
+	return
+}
+
+//	Returns the difference of `me` and `hex`.
+func (me Hex) Sub(hex Hex) Hex {
+	return Hex{me.Q - hex.Q, me.R - hex.R}
+}
+
+//	Returns the axial coordinates of `me`.
+func (me HexCube) Hex() Hex {
+	return Hex{me.Q, me.R}
+}
+
+//	Returns the axial coordinates of `me` in a grid of the specified `kind`.
+func (me HexOffset) Hex(kind HexOffsetKind) Hex {
+	switch kind {
+	case HexOddR:
+		return Hex{me.Col - (me.Row-(me.Row&1))/2, me.Row}
+	case HexEvenR:
+		return Hex{me.Col - (me.Row+(me.Row&1))/2, me.Row}
+	case HexOddQ:
+		return Hex{me.Col, me.Row - (me.Col-(me.Col&1))/2}
+	}
+	return Hex{me.Col, me.Row - (me.Col+(me.Col&1))/2}
+}
+
+//	Specifies whether hex cells have a corner (pointy) or an edge (flat) at the top.
+type HexOrientation int
+
+const (
+	//	Cells have corners at the top and bottom, and are arranged in rows.
+	HexPointy HexOrientation = iota
+
+	//	Cells have edges at the top and bottom, and are arranged in columns.
+	HexFlat
+)
+
+//	Describes how the cells of a hexagonal grid map to `Vec2` pixel positions.
+type HexLayout struct {
+	//	Pointy or flat.
+	Orientation HexOrientation
+
+	//	The distance from a cell's center to its corners, per axis (equal for regular hexagons).
+	Size Vec2
+
+	//	The pixel position of the center of cell `(0, 0)`.
+	Origin Vec2
+}
+
+//	Returns the corners of the cell `hex`, counter-clockwise.
+func (me *HexLayout) Corners(hex Hex) (corners Polygon) {
+	center, start := me.ToPixel(hex), math.Pi/6
+	if me.Orientation == HexFlat {
+		start = 0
+	}
+	corners = make(Polygon, 6)
+	for i := range corners {
+		sin, cos := math.Sincos(start + float64(i)*math.Pi/3)
+		corners[i] = Vec2{center.X + cos*me.Size.X, center.Y + sin*me.Size.Y}
+	}
+	return
+}
+
+//	Returns the cell containing the pixel position `point`.
+func (me *HexLayout) FromPixel(point *Vec2) Hex {
+	x, y := (point.X-me.Origin.X)/me.Size.X, (point.Y-me.Origin.Y)/me.Size.Y
+	if me.Orientation == HexFlat {
+		return HexRound(x*2/3, -x/3+y*math.Sqrt(3)/3)
+	}
+	return HexRound(x*math.Sqrt(3)/3-y/3, y*2/3)
+}
+
+//	Returns the pixel position of the center of the cell `hex`.
+func (me *HexLayout) ToPixel(hex Hex) *Vec2 {
+	q, r := float64(hex.Q), float64(hex.R)
+	if me.Orientation == HexFlat {
+		return &Vec2{me.Origin.X + me.Size.X*1.5*q, me.Origin.Y + me.Size.Y*math.Sqrt(3)*(q/2+r)}
+	}
+	return &Vec2{me.Origin.X + me.Size.X*math.Sqrt(3)*(q+r/2), me.Origin.Y + me.Size.Y*1.5*r}
+}
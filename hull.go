@@ -0,0 +1,339 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents a triangulated 3D convex hull.
+type ConvexHull struct {
+	//	The hull vertices, a subset of the input points.
+	Vertices []Vec3
+
+	//	The hull triangles.
+	Faces []ConvexHullFace
+}
+
+//	Represents a triangle of a `ConvexHull`.
+type ConvexHullFace struct {
+	//	Indices into `ConvexHull.Vertices`, counter-clockwise when seen from outside the hull.
+	V [3]int
+
+	//	`Adj[i]` is the index (into `ConvexHull.Faces`) of the face sharing the edge from `V[i]` to `V[(i+1)%3]`.
+	Adj [3]int
+
+	//	The outward-facing supporting plane.
+	Plane Plane
+}
+
+type qhFace struct {
+	v, adj  [3]int
+	plane   Plane
+	outside []int
+	dead    bool
+}
+
+type qhHorizonEdge struct {
+	a, b, nbr int
+}
+
+type quickhull struct {
+	points []Vec3
+	faces  []qhFace
+	eps    float64
+}
+
+//	Returns a new `*ConvexHull` of `points`, computed with the Quickhull algorithm.
+//
+//	Points within a tolerance (derived from `Epsilon` and the magnitude of the input coordinates) of a hull face
+//	are considered to lie on or inside the hull, so duplicate and coplanar points are discarded and coplanar
+//	regions are triangulated. Returns `nil` if fewer than 4 of the `points` are non-coplanar.
+func NewConvexHull(points []Vec3) (me *ConvexHull) {
+	q := quickhull{points: points}
+	if !q.init() {
+		return
+	}
+	for f := 0; f < len(q.faces); f++ {
+		for !q.faces[f].dead && len(q.faces[f].outside) > 0 {
+			q.addPoint(f)
+		}
+	}
+	return q.build()
+}
+
+func (me *quickhull) dist(f, p int) float64 {
+	return me.faces[f].plane.SignedDistance(&me.points[p])
+}
+
+func (me *quickhull) init() bool {
+	if len(me.points) < 4 {
+		return false
+	}
+	var maxAbs Vec3
+	var ext [6]int
+	for i := range me.points {
+		p := &me.points[i]
+		maxAbs.Set(math.Max(maxAbs.X, math.Abs(p.X)), math.Max(maxAbs.Y, math.Abs(p.Y)), math.Max(maxAbs.Z, math.Abs(p.Z)))
+		for a := 0; a < 3; a++ {
+			if v := p.at(a); v < me.points[ext[a*2]].at(a) {
+				ext[a*2] = i
+			} else if v > me.points[ext[a*2+1]].at(a) {
+				ext[a*2+1] = i
+			}
+		}
+	}
+	me.eps = 3 * Epsilon * (maxAbs.X + maxAbs.Y + maxAbs.Z)
+	//	initial simplex: the most distant pair of extreme points, then the farthest point from their line, then from their plane
+	var i0, i1, i2, i3 int
+	best := -1.0
+	for a := 0; a < 3; a++ {
+		if d := me.points[ext[a*2]].DistanceSq(&me.points[ext[a*2+1]]); d > best {
+			best, i0, i1 = d, ext[a*2], ext[a*2+1]
+		}
+	}
+	if best <= me.eps*me.eps {
+		return false
+	}
+	var ab, ap Vec3
+	ab.SetFromSub(&me.points[i1], &me.points[i0])
+	best = -1
+	for i := range me.points {
+		ap.SetFromSub(&me.points[i], &me.points[i0])
+		if d := ab.Cross(&ap).Length() / ab.Length(); d > best {
+			best, i2 = d, i
+		}
+	}
+	if best <= me.eps*me.eps {
+		return false
+	}
+	plane := NewPlaneFromPoints(&me.points[i0], &me.points[i1], &me.points[i2])
+	best = -1
+	for i := range me.points {
+		if d := math.Abs(plane.SignedDistance(&me.points[i])); d > best {
+			best, i3 = d, i
+		}
+	}
+	if best <= me.eps {
+		return false
+	}
+	if plane.SignedDistance(&me.points[i3]) > 0 {
+		i1, i2 = i2, i1
+	}
+	//	the 4 faces, each with its edges' neighbors: face k is opposite vertex k of the tetrahedron (i0, i1, i2, i3)
+	me.faces = []qhFace{
+		{v: [3]int{i1, i3, i2}, adj: [3]int{2, 1, 3}},
+		{v: [3]int{i2, i3, i0}, adj: [3]int{0, 2, 3}},
+		{v: [3]int{i0, i3, i1}, adj: [3]int{1, 0, 3}},
+		{v: [3]int{i0, i1, i2}, adj: [3]int{2, 0, 1}},
+	}
+	for f := range me.faces {
+		me.updatePlane(f)
+	}
+	all := make([]int, 0, len(me.points))
+	for i := range me.points {
+		if i != i0 && i != i1 && i != i2 && i != i3 {
+			all = append(all, i)
+		}
+	}
+	me.assign(all, []int{0, 1, 2, 3})
+	return true
+}
+
+func (me *quickhull) updatePlane(f int) {
+	face := &me.faces[f]
+	face.plane = *NewPlaneFromPoints(&me.points[face.v[0]], &me.points[face.v[1]], &me.points[face.v[2]])
+}
+
+//	Assigns each of the `points` to the outside set of the face among `faces` it lies farthest in front of, if any.
+func (me *quickhull) assign(points, faces []int) {
+	for _, p := range points {
+		best, bestDist := -1, me.eps
+		for _, f := range faces {
+			if d := me.dist(f, p); d > bestDist {
+				best, bestDist = f, d
+			}
+		}
+		if best >= 0 {
+			me.faces[best].outside = append(me.faces[best].outside, p)
+		}
+	}
+}
+
+//	Adds the farthest point in the outside set of face `f` to the hull.
+func (me *quickhull) addPoint(f int) {
+	eye, bestDist := -1, -1.0
+	for _, p := range me.faces[f].outside {
+		if d := me.dist(f, p); d > bestDist {
+			eye, bestDist = p, d
+		}
+	}
+	var horizon []qhHorizonEdge
+	var visible []int
+	me.horizon(eye, f, -1, &horizon, &visible)
+	first := len(me.faces)
+	for i, h := range horizon {
+		nf := len(me.faces)
+		prev, next := first+(i+len(horizon)-1)%len(horizon), first+(i+1)%len(horizon)
+		me.faces = append(me.faces, qhFace{v: [3]int{h.a, h.b, eye}, adj: [3]int{h.nbr, next, prev}})
+		me.updatePlane(nf)
+		nbr := &me.faces[h.nbr]
+		for k := 0; k < 3; k++ {
+			if nbr.v[k] == h.b && nbr.v[(k+1)%3] == h.a {
+				nbr.adj[k] = nf
+			}
+		}
+	}
+	var orphans, newFaces []int
+	for _, v := range visible {
+		for _, p := range me.faces[v].outside {
+			if p != eye {
+				orphans = append(orphans, p)
+			}
+		}
+		me.faces[v].outside = nil
+	}
+	for nf := first; nf < len(me.faces); nf++ {
+		newFaces = append(newFaces, nf)
+	}
+	me.assign(orphans, newFaces)
+}
+
+//	Marks face `f` (entered via its edge `e0`, or -1 for the initial face) and all connected faces visible from
+//	the point `eye` as dead, and collects the horizon edges in counter-clockwise order.
+func (me *quickhull) horizon(eye, f, e0 int, horizon *[]qhHorizonEdge, visible *[]int) {
+	me.faces[f].dead = true
+	*visible = append(*visible, f)
+	start, n := 0, 3
+	if e0 >= 0 {
+		start, n = e0+1, 2
+	}
+	for i := 0; i < n; i++ {
+		e := (start + i) % 3
+		face := &me.faces[f]
+		nbr := face.adj[e]
+		if me.faces[nbr].dead {
+			continue
+		}
+		if me.dist(nbr, eye) > me.eps {
+			ne := 0
+			for k := 0; k < 3; k++ {
+				if me.faces[nbr].adj[k] == f {
+					ne = k
+				}
+			}
+			me.horizon(eye, nbr, ne, horizon, visible)
+		} else {
+			*horizon = append(*horizon, qhHorizonEdge{face.v[e], face.v[(e+1)%3], nbr})
+		}
+	}
+}
+
+func (me *quickhull) build() (hull *ConvexHull) {
+	hull = new(ConvexHull)
+	vmap, fmap := map[int]int{}, map[int]int{}
+	for f := range me.faces {
+		if !me.faces[f].dead {
+			fmap[f] = len(fmap)
+		}
+	}
+	hull.Faces = make([]ConvexHullFace, len(fmap))
+	for f := range me.faces {
+		if me.faces[f].dead {
+			continue
+		}
+		face, out := &me.faces[f], &hull.Faces[fmap[f]]
+		for k := 0; k < 3; k++ {
+			vi, ok := vmap[face.v[k]]
+			if !ok {
+				vi = len(vmap)
+				vmap[face.v[k]] = vi
+				hull.Vertices = append(hull.Vertices, me.points[face.v[k]])
+			}
+			out.V[k], out.Adj[k] = vi, fmap[face.adj[k]]
+		}
+		out.Plane = face.plane
+	}
+	return
+}
+
+//	Returns the center of mass of the solid enclosed by `me`.
+func (me *ConvexHull) Centroid() (c *Vec3) {
+	var t Vec3
+	ref, vol := me.reference(), 0.0
+	c = new(Vec3)
+	for i := range me.Faces {
+		v := me.tetraVolume(ref, i)
+		t.SetFromAddAdd(ref, &me.Vertices[me.Faces[i].V[0]], &me.Vertices[me.Faces[i].V[1]])
+		t.Add(&me.Vertices[me.Faces[i].V[2]])
+		c.SetFromAddScaled(c, &t, v*0.25)
+		vol += v
+	}
+	if vol != 0 {
+		c.Divide(vol)
+	}
+	return
+}
+
+//	Returns whether `point` lies within (or on the boundary of) `me`.
+func (me *ConvexHull) ContainsPoint(point *Vec3) bool {
+	for i := range me.Faces {
+		if me.Faces[i].Plane.SignedDistance(point) > EpsilonEqFloatFactor {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns the hull triangles as an index buffer into `Vertices`, 3 indices per triangle.
+func (me *ConvexHull) Indices() (indices []uint32) {
+	indices = make([]uint32, 0, len(me.Faces)*3)
+	for i := range me.Faces {
+		indices = append(indices, uint32(me.Faces[i].V[0]), uint32(me.Faces[i].V[1]), uint32(me.Faces[i].V[2]))
+	}
+	return
+}
+
+//	An interior point (the vertex average) used as the apex of the tetrahedra decomposing `me`.
+func (me *ConvexHull) reference() (ref *Vec3) {
+	ref = new(Vec3)
+	for i := range me.Vertices {
+		ref.Add(&me.Vertices[i])
+	}
+	if len(me.Vertices) > 0 {
+		ref.Divide(float64(len(me.Vertices)))
+	}
+	return
+}
+
+//	Returns the vertex of `me` that is farthest along `dir`.
+func (me *ConvexHull) Support(dir Vec3) Vec3 {
+	return ConvexPoints(me.Vertices).Support(dir)
+}
+
+//	Returns the total surface area of `me`.
+func (me *ConvexHull) SurfaceArea() (area float64) {
+	var ab, ac Vec3
+	for i := range me.Faces {
+		a := &me.Vertices[me.Faces[i].V[0]]
+		ab.SetFromSub(&me.Vertices[me.Faces[i].V[1]], a)
+		ac.SetFromSub(&me.Vertices[me.Faces[i].V[2]], a)
+		area += 0.5 * ab.Cross(&ac).Magnitude()
+	}
+	return
+}
+
+func (me *ConvexHull) tetraVolume(ref *Vec3, f int) float64 {
+	var a, b, c Vec3
+	a.SetFromSub(&me.Vertices[me.Faces[f].V[0]], ref)
+	b.SetFromSub(&me.Vertices[me.Faces[f].V[1]], ref)
+	c.SetFromSub(&me.Vertices[me.Faces[f].V[2]], ref)
+	return a.Dot(b.Cross(&c)) / 6
+}
+
+//	Returns the volume enclosed by `me`.
+func (me *ConvexHull) Volume() (vol float64) {
+	ref := me.reference()
+	for i := range me.Faces {
+		vol += me.tetraVolume(ref, i)
+	}
+	return
+}
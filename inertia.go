@@ -0,0 +1,100 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Returns a new `*Mat3` representing the body-space inertia tensor of a solid box of the specified `mass` and `halfExtents`, centered at the origin.
+func NewInertiaBox(mass float64, halfExtents *Vec3) (mat *Mat3) {
+	x, y, z := halfExtents.X*halfExtents.X, halfExtents.Y*halfExtents.Y, halfExtents.Z*halfExtents.Z
+	mat = new(Mat3)
+	mat.Scaling(&Vec3{y + z, x + z, x + y})
+	mat.Mult1(mass / 3)
+	return
+}
+
+//	Returns a new `*Mat3` representing the body-space inertia tensor of a solid capsule of the specified `mass`,
+//	centered at the origin, with its axis along Y. `height` is the length of the cylindrical section, excluding both hemispherical caps.
+func NewInertiaCapsule(mass, radius, height float64) (mat *Mat3) {
+	rr := radius * radius
+	cylVol, sphVol := math.Pi*rr*height, (4.0/3)*math.Pi*rr*radius
+	mc := mass * cylVol / (cylVol + sphVol)
+	ms := mass - mc
+	axial := mc*rr*0.5 + ms*rr*0.4
+	lateral := mc*(height*height/12+rr*0.25) + ms*(rr*0.4+height*height*0.25+height*radius*0.375)
+	mat = new(Mat3)
+	mat.Scaling(&Vec3{lateral, axial, lateral})
+	return
+}
+
+//	Returns a new `*Mat3` representing the body-space inertia tensor of a solid cylinder of the specified `mass`,
+//	centered at the origin, with its axis along Y.
+func NewInertiaCylinder(mass, radius, height float64) (mat *Mat3) {
+	rr := radius * radius
+	lateral := mass * (3*rr + height*height) / 12
+	mat = new(Mat3)
+	mat.Scaling(&Vec3{lateral, mass * rr * 0.5, lateral})
+	return
+}
+
+//	Returns a new `*Mat3` representing the body-space inertia tensor of a solid sphere of the specified `mass` and `radius`.
+func NewInertiaSphere(mass, radius float64) (mat *Mat3) {
+	i := 0.4 * mass * radius * radius
+	mat = new(Mat3)
+	mat.Scaling(&Vec3{i, i, i})
+	return
+}
+
+//	Computes the mass properties of the solid enclosed by the closed triangle mesh described by `positions` and `indices`
+//	(3 per triangle, counter-clockwise when seen from outside) at the specified uniform `density`.
+//
+//	Returns the `mass`, the center of mass `com`, and the inertia tensor `mat` relative to `com`.
+//	Uses the polyhedral mass properties algorithm by Eberly, which integrates over the mesh surface via the divergence theorem.
+func NewInertiaTriMesh(positions []Vec3, indices []uint32, density float64) (mass float64, com *Vec3, mat *Mat3) {
+	var in [10]float64
+	var e1, e2, d Vec3
+	for i := 0; i+2 < len(indices); i += 3 {
+		p0, p1, p2 := &positions[indices[i]], &positions[indices[i+1]], &positions[indices[i+2]]
+		e1.SetFromSub(p1, p0)
+		e2.SetFromSub(p2, p0)
+		d.SetFromCrossOf(&e1, &e2)
+		f1x, f2x, f3x, g0x, g1x, g2x := inertiaSubexpr(p0.X, p1.X, p2.X)
+		_, f2y, f3y, g0y, g1y, g2y := inertiaSubexpr(p0.Y, p1.Y, p2.Y)
+		_, f2z, f3z, g0z, g1z, g2z := inertiaSubexpr(p0.Z, p1.Z, p2.Z)
+		in[0] += d.X * f1x
+		in[1] += d.X * f2x
+		in[2] += d.Y * f2y
+		in[3] += d.Z * f2z
+		in[4] += d.X * f3x
+		in[5] += d.Y * f3y
+		in[6] += d.Z * f3z
+		in[7] += d.X * (p0.Y*g0x + p1.Y*g1x + p2.Y*g2x)
+		in[8] += d.Y * (p0.Z*g0y + p1.Z*g1y + p2.Z*g2y)
+		in[9] += d.Z * (p0.X*g0z + p1.X*g1z + p2.X*g2z)
+	}
+	for i, m := range [10]float64{1.0 / 6, 1.0 / 24, 1.0 / 24, 1.0 / 24, 1.0 / 60, 1.0 / 60, 1.0 / 60, 1.0 / 120, 1.0 / 120, 1.0 / 120} {
+		in[i] *= m * density
+	}
+	mass, com, mat = in[0], new(Vec3), new(Mat3)
+	if mass == 0 {
+		return
+	}
+	com.Set(in[1]/mass, in[2]/mass, in[3]/mass)
+	cx, cy, cz := com.X, com.Y, com.Z
+	xy, yz, xz := -(in[7] - mass*cx*cy), -(in[8] - mass*cy*cz), -(in[9] - mass*cz*cx)
+	mat[0], mat[3], mat[6] = in[5]+in[6]-mass*(cy*cy+cz*cz), xy, xz
+	mat[1], mat[4], mat[7] = xy, in[4]+in[6]-mass*(cz*cz+cx*cx), yz
+	mat[2], mat[5], mat[8] = xz, yz, in[4]+in[5]-mass*(cx*cx+cy*cy)
+	return
+}
+
+func inertiaSubexpr(w0, w1, w2 float64) (f1, f2, f3, g0, g1, g2 float64) {
+	t0 := w0 + w1
+	f1 = t0 + w2
+	t1 := w0 * w0
+	t2 := t1 + w1*t0
+	f2 = t2 + w2*f1
+	f3 = w0*t1 + w1*t2 + w2*f2
+	g0, g1, g2 = f2+w0*(f1+w0), f2+w1*(f1+w1), f2+w2*(f1+w2)
+	return
+}
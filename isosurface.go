@@ -0,0 +1,583 @@
+package unum
+
+var (
+	//	`ScalarGrid.DualContour` treats eigenvalues of a cell's normal matrix below this fraction of the largest as 0,
+	//	so that flat and edge-like cells keep their vertex near the crossings' mass point along the free directions.
+	DualContourThreshold = 0.1
+)
+
+//	Marching cubes tables: corners of a cell as grid offsets, the corners joined by each edge (lower first), the
+//	bitmask of edges crossed for each of the 256 combinations of corners below the iso value (bit `i` for corner `i`),
+//	and the triangles (as edge triples, terminated by -1) for each combination. Ambiguous faces separate the corners
+//	below the iso value on both cells sharing them, and no triangle lies within a face, so the surface is watertight
+//	and manifold.
+var (
+	marchingCubesCorners     = [8][3]int{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1}}
+	marchingCubesEdgeCorners = [12][2]int{{0, 1}, {1, 2}, {3, 2}, {0, 3}, {4, 5}, {5, 6}, {7, 6}, {4, 7}, {0, 4}, {1, 5}, {2, 6}, {3, 7}}
+	marchingCubesEdges       = [256]uint16{
+		0x000, 0x109, 0x203, 0x30a, 0x406, 0x50f, 0x605, 0x70c,
+		0x80c, 0x905, 0xa0f, 0xb06, 0xc0a, 0xd03, 0xe09, 0xf00,
+		0x190, 0x099, 0x393, 0x29a, 0x596, 0x49f, 0x795, 0x69c,
+		0x99c, 0x895, 0xb9f, 0xa96, 0xd9a, 0xc93, 0xf99, 0xe90,
+		0x230, 0x339, 0x033, 0x13a, 0x636, 0x73f, 0x435, 0x53c,
+		0xa3c, 0xb35, 0x83f, 0x936, 0xe3a, 0xf33, 0xc39, 0xd30,
+		0x3a0, 0x2a9, 0x1a3, 0x0aa, 0x7a6, 0x6af, 0x5a5, 0x4ac,
+		0xbac, 0xaa5, 0x9af, 0x8a6, 0xfaa, 0xea3, 0xda9, 0xca0,
+		0x460, 0x569, 0x663, 0x76a, 0x066, 0x16f, 0x265, 0x36c,
+		0xc6c, 0xd65, 0xe6f, 0xf66, 0x86a, 0x963, 0xa69, 0xb60,
+		0x5f0, 0x4f9, 0x7f3, 0x6fa, 0x1f6, 0x0ff, 0x3f5, 0x2fc,
+		0xdfc, 0xcf5, 0xfff, 0xef6, 0x9fa, 0x8f3, 0xbf9, 0xaf0,
+		0x650, 0x759, 0x453, 0x55a, 0x256, 0x35f, 0x055, 0x15c,
+		0xe5c, 0xf55, 0xc5f, 0xd56, 0xa5a, 0xb53, 0x859, 0x950,
+		0x7c0, 0x6c9, 0x5c3, 0x4ca, 0x3c6, 0x2cf, 0x1c5, 0x0cc,
+		0xfcc, 0xec5, 0xdcf, 0xcc6, 0xbca, 0xac3, 0x9c9, 0x8c0,
+		0x8c0, 0x9c9, 0xac3, 0xbca, 0xcc6, 0xdcf, 0xec5, 0xfcc,
+		0x0cc, 0x1c5, 0x2cf, 0x3c6, 0x4ca, 0x5c3, 0x6c9, 0x7c0,
+		0x950, 0x859, 0xb53, 0xa5a, 0xd56, 0xc5f, 0xf55, 0xe5c,
+		0x15c, 0x055, 0x35f, 0x256, 0x55a, 0x453, 0x759, 0x650,
+		0xaf0, 0xbf9, 0x8f3, 0x9fa, 0xef6, 0xfff, 0xcf5, 0xdfc,
+		0x2fc, 0x3f5, 0x0ff, 0x1f6, 0x6fa, 0x7f3, 0x4f9, 0x5f0,
+		0xb60, 0xa69, 0x963, 0x86a, 0xf66, 0xe6f, 0xd65, 0xc6c,
+		0x36c, 0x265, 0x16f, 0x066, 0x76a, 0x663, 0x569, 0x460,
+		0xca0, 0xda9, 0xea3, 0xfaa, 0x8a6, 0x9af, 0xaa5, 0xbac,
+		0x4ac, 0x5a5, 0x6af, 0x7a6, 0x0aa, 0x1a3, 0x2a9, 0x3a0,
+		0xd30, 0xc39, 0xf33, 0xe3a, 0x936, 0x83f, 0xb35, 0xa3c,
+		0x53c, 0x435, 0x73f, 0x636, 0x13a, 0x033, 0x339, 0x230,
+		0xe90, 0xf99, 0xc93, 0xd9a, 0xa96, 0xb9f, 0x895, 0x99c,
+		0x69c, 0x795, 0x49f, 0x596, 0x29a, 0x393, 0x099, 0x190,
+		0xf00, 0xe09, 0xd03, 0xc0a, 0xb06, 0xa0f, 0x905, 0x80c,
+		0x70c, 0x605, 0x50f, 0x406, 0x30a, 0x203, 0x109, 0x000,
+	}
+	marchingCubesTriangles = [256][16]int8{
+		{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 3, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 10, 2, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 2, 0, 9, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 9, 10, 2, 8, 9, 2, 3, 8, -1, -1, -1, -1, -1, -1, -1},
+		{2, 11, 3, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 2, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 11, 3, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 11, 8, 1, 2, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 11, 3, 1, 10, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 10, 11, 0, 1, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 3, 0, 10, 11, 0, 9, 10, -1, -1, -1, -1, -1, -1, -1},
+		{8, 10, 11, 8, 9, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{4, 8, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 3, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 9, 1, 7, 4, 1, 3, 7, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 3, 7, 1, 10, 2, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 2, 0, 9, 10, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{2, 9, 10, 2, 4, 9, 2, 7, 4, 2, 3, 7, -1, -1, -1, -1},
+		{2, 11, 3, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 11, 7, 0, 2, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 11, 3, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 9, 1, 7, 4, 1, 11, 7, 1, 2, 11, -1, -1, -1, -1},
+		{1, 11, 3, 1, 10, 11, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 11, 7, 0, 10, 11, 0, 1, 10, -1, -1, -1, -1},
+		{0, 11, 3, 0, 10, 11, 0, 9, 10, 4, 8, 7, -1, -1, -1, -1},
+		{4, 11, 7, 4, 10, 11, 4, 9, 10, -1, -1, -1, -1, -1, -1, -1},
+		{4, 5, 9, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 1, 0, 4, 5, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 5, 1, 8, 4, 1, 3, 8, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 10, 2, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 2, 0, 5, 10, 0, 4, 5, -1, -1, -1, -1, -1, -1, -1},
+		{2, 5, 10, 2, 4, 5, 2, 8, 4, 2, 3, 8, -1, -1, -1, -1},
+		{2, 11, 3, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 2, 11, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 1, 0, 4, 5, 2, 11, 3, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 5, 1, 8, 4, 1, 11, 8, 1, 2, 11, -1, -1, -1, -1},
+		{1, 11, 3, 1, 10, 11, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 10, 11, 0, 1, 10, 4, 5, 9, -1, -1, -1, -1},
+		{0, 11, 3, 0, 10, 11, 0, 5, 10, 0, 4, 5, -1, -1, -1, -1},
+		{4, 11, 8, 4, 10, 11, 4, 5, 10, -1, -1, -1, -1, -1, -1, -1},
+		{5, 8, 7, 5, 9, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 9, 0, 7, 5, 0, 3, 7, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 1, 0, 7, 5, 0, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{1, 7, 5, 1, 3, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, 5, 8, 7, 5, 9, 8, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 9, 0, 7, 5, 0, 3, 7, 1, 10, 2, -1, -1, -1, -1},
+		{0, 10, 2, 0, 5, 10, 0, 7, 5, 0, 8, 7, -1, -1, -1, -1},
+		{2, 5, 10, 2, 7, 5, 2, 3, 7, -1, -1, -1, -1, -1, -1, -1},
+		{2, 11, 3, 5, 8, 7, 5, 9, 8, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 9, 0, 7, 5, 0, 11, 7, 0, 2, 11, -1, -1, -1, -1},
+		{0, 5, 1, 0, 7, 5, 0, 8, 7, 2, 11, 3, -1, -1, -1, -1},
+		{1, 7, 5, 1, 11, 7, 1, 2, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 11, 3, 1, 10, 11, 5, 8, 7, 5, 9, 8, -1, -1, -1, -1},
+		{0, 5, 9, 0, 7, 5, 0, 11, 7, 0, 10, 11, 0, 1, 10, -1},
+		{0, 11, 3, 0, 10, 11, 0, 5, 10, 0, 7, 5, 0, 8, 7, -1},
+		{5, 11, 7, 5, 10, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{5, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 3, 8, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{1, 6, 2, 1, 5, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 6, 2, 1, 5, 6, -1, -1, -1, -1, -1, -1, -1},
+		{0, 6, 2, 0, 5, 6, 0, 9, 5, -1, -1, -1, -1, -1, -1, -1},
+		{2, 5, 6, 2, 9, 5, 2, 8, 9, 2, 3, 8, -1, -1, -1, -1},
+		{2, 11, 3, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 2, 11, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 11, 3, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 11, 8, 1, 2, 11, 5, 6, 10, -1, -1, -1, -1},
+		{1, 11, 3, 1, 6, 11, 1, 5, 6, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 6, 11, 0, 5, 6, 0, 1, 5, -1, -1, -1, -1},
+		{0, 11, 3, 0, 6, 11, 0, 5, 6, 0, 9, 5, -1, -1, -1, -1},
+		{5, 8, 9, 5, 11, 8, 5, 6, 11, -1, -1, -1, -1, -1, -1, -1},
+		{4, 8, 7, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 3, 7, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 4, 8, 7, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 9, 1, 7, 4, 1, 3, 7, 5, 6, 10, -1, -1, -1, -1},
+		{1, 6, 2, 1, 5, 6, 4, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 3, 7, 1, 6, 2, 1, 5, 6, -1, -1, -1, -1},
+		{0, 6, 2, 0, 5, 6, 0, 9, 5, 4, 8, 7, -1, -1, -1, -1},
+		{2, 5, 6, 2, 9, 5, 2, 4, 9, 2, 7, 4, 2, 3, 7, -1},
+		{2, 11, 3, 4, 8, 7, 5, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 4, 0, 11, 7, 0, 2, 11, 5, 6, 10, -1, -1, -1, -1},
+		{0, 9, 1, 2, 11, 3, 4, 8, 7, 5, 6, 10, -1, -1, -1, -1},
+		{1, 4, 9, 1, 7, 4, 1, 11, 7, 1, 2, 11, 5, 6, 10, -1},
+		{1, 11, 3, 1, 6, 11, 1, 5, 6, 4, 8, 7, -1, -1, -1, -1},
+		{0, 7, 4, 0, 11, 7, 0, 6, 11, 0, 5, 6, 0, 1, 5, -1},
+		{0, 11, 3, 0, 6, 11, 0, 5, 6, 0, 9, 5, 4, 8, 7, -1},
+		{11, 5, 6, 11, 9, 5, 11, 4, 9, 11, 7, 4, -1, -1, -1, -1},
+		{4, 10, 9, 4, 6, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 4, 10, 9, 4, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 1, 0, 6, 10, 0, 4, 6, -1, -1, -1, -1, -1, -1, -1},
+		{1, 6, 10, 1, 4, 6, 1, 8, 4, 1, 3, 8, -1, -1, -1, -1},
+		{1, 6, 2, 1, 4, 6, 1, 9, 4, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 6, 2, 1, 4, 6, 1, 9, 4, -1, -1, -1, -1},
+		{0, 6, 2, 0, 4, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 4, 6, 2, 8, 4, 2, 3, 8, -1, -1, -1, -1, -1, -1, -1},
+		{2, 11, 3, 4, 10, 9, 4, 6, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 8, 0, 2, 11, 4, 10, 9, 4, 6, 10, -1, -1, -1, -1},
+		{0, 10, 1, 0, 6, 10, 0, 4, 6, 2, 11, 3, -1, -1, -1, -1},
+		{1, 6, 10, 1, 4, 6, 1, 8, 4, 1, 11, 8, 1, 2, 11, -1},
+		{1, 11, 3, 1, 6, 11, 1, 4, 6, 1, 9, 4, -1, -1, -1, -1},
+		{11, 4, 6, 11, 9, 4, 11, 1, 9, 11, 0, 1, 11, 8, 0, -1},
+		{0, 11, 3, 0, 6, 11, 0, 4, 6, -1, -1, -1, -1, -1, -1, -1},
+		{4, 11, 8, 4, 6, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{6, 8, 7, 6, 9, 8, 6, 10, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 9, 0, 6, 10, 0, 7, 6, 0, 3, 7, -1, -1, -1, -1},
+		{0, 10, 1, 0, 6, 10, 0, 7, 6, 0, 8, 7, -1, -1, -1, -1},
+		{1, 6, 10, 1, 7, 6, 1, 3, 7, -1, -1, -1, -1, -1, -1, -1},
+		{1, 6, 2, 1, 7, 6, 1, 8, 7, 1, 9, 8, -1, -1, -1, -1},
+		{9, 2, 1, 9, 6, 2, 9, 7, 6, 9, 3, 7, 9, 0, 3, -1},
+		{0, 6, 2, 0, 7, 6, 0, 8, 7, -1, -1, -1, -1, -1, -1, -1},
+		{2, 7, 6, 2, 3, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 11, 3, 6, 8, 7, 6, 9, 8, 6, 10, 9, -1, -1, -1, -1},
+		{0, 10, 9, 0, 6, 10, 0, 7, 6, 0, 11, 7, 0, 2, 11, -1},
+		{0, 10, 1, 0, 6, 10, 0, 7, 6, 0, 8, 7, 2, 11, 3, -1},
+		{1, 6, 10, 1, 7, 6, 1, 11, 7, 1, 2, 11, -1, -1, -1, -1},
+		{1, 11, 3, 1, 6, 11, 1, 7, 6, 1, 8, 7, 1, 9, 8, -1},
+		{0, 1, 9, 6, 11, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 11, 3, 0, 6, 11, 0, 7, 6, 0, 8, 7, -1, -1, -1, -1},
+		{6, 11, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{6, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 3, 8, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 10, 2, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 2, 0, 9, 10, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{2, 9, 10, 2, 8, 9, 2, 3, 8, 6, 7, 11, -1, -1, -1, -1},
+		{2, 7, 3, 2, 6, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 8, 0, 6, 7, 0, 2, 6, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 7, 3, 2, 6, 7, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 7, 8, 1, 6, 7, 1, 2, 6, -1, -1, -1, -1},
+		{1, 7, 3, 1, 6, 7, 1, 10, 6, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 8, 0, 6, 7, 0, 10, 6, 0, 1, 10, -1, -1, -1, -1},
+		{0, 7, 3, 0, 6, 7, 0, 10, 6, 0, 9, 10, -1, -1, -1, -1},
+		{6, 9, 10, 6, 8, 9, 6, 7, 8, -1, -1, -1, -1, -1, -1, -1},
+		{4, 11, 6, 4, 8, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 6, 4, 0, 11, 6, 0, 3, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 4, 11, 6, 4, 8, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 9, 1, 6, 4, 1, 11, 6, 1, 3, 11, -1, -1, -1, -1},
+		{1, 10, 2, 4, 11, 6, 4, 8, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 6, 4, 0, 11, 6, 0, 3, 11, 1, 10, 2, -1, -1, -1, -1},
+		{0, 10, 2, 0, 9, 10, 4, 11, 6, 4, 8, 11, -1, -1, -1, -1},
+		{9, 6, 4, 9, 11, 6, 9, 3, 11, 9, 2, 3, 9, 10, 2, -1},
+		{2, 8, 3, 2, 4, 8, 2, 6, 4, -1, -1, -1, -1, -1, -1, -1},
+		{0, 6, 4, 0, 2, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 8, 3, 2, 4, 8, 2, 6, 4, -1, -1, -1, -1},
+		{1, 4, 9, 1, 6, 4, 1, 2, 6, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 3, 1, 4, 8, 1, 6, 4, 1, 10, 6, -1, -1, -1, -1},
+		{0, 6, 4, 0, 10, 6, 0, 1, 10, -1, -1, -1, -1, -1, -1, -1},
+		{3, 4, 8, 3, 6, 4, 3, 10, 6, 3, 9, 10, 3, 0, 9, -1},
+		{4, 10, 6, 4, 9, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{4, 5, 9, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 4, 5, 9, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 1, 0, 4, 5, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 4, 5, 1, 8, 4, 1, 3, 8, 6, 7, 11, -1, -1, -1, -1},
+		{1, 10, 2, 4, 5, 9, 6, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 10, 2, 4, 5, 9, 6, 7, 11, -1, -1, -1, -1},
+		{0, 10, 2, 0, 5, 10, 0, 4, 5, 6, 7, 11, -1, -1, -1, -1},
+		{2, 5, 10, 2, 4, 5, 2, 8, 4, 2, 3, 8, 6, 7, 11, -1},
+		{2, 7, 3, 2, 6, 7, 4, 5, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 8, 0, 6, 7, 0, 2, 6, 4, 5, 9, -1, -1, -1, -1},
+		{0, 5, 1, 0, 4, 5, 2, 7, 3, 2, 6, 7, -1, -1, -1, -1},
+		{1, 4, 5, 1, 8, 4, 1, 7, 8, 1, 6, 7, 1, 2, 6, -1},
+		{1, 7, 3, 1, 6, 7, 1, 10, 6, 4, 5, 9, -1, -1, -1, -1},
+		{0, 7, 8, 0, 6, 7, 0, 10, 6, 0, 1, 10, 4, 5, 9, -1},
+		{0, 7, 3, 0, 6, 7, 0, 10, 6, 0, 5, 10, 0, 4, 5, -1},
+		{8, 6, 7, 8, 10, 6, 8, 5, 10, 8, 4, 5, -1, -1, -1, -1},
+		{5, 11, 6, 5, 8, 11, 5, 9, 8, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 9, 0, 6, 5, 0, 11, 6, 0, 3, 11, -1, -1, -1, -1},
+		{0, 5, 1, 0, 6, 5, 0, 11, 6, 0, 8, 11, -1, -1, -1, -1},
+		{1, 6, 5, 1, 11, 6, 1, 3, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 10, 2, 5, 11, 6, 5, 8, 11, 5, 9, 8, -1, -1, -1, -1},
+		{0, 5, 9, 0, 6, 5, 0, 11, 6, 0, 3, 11, 1, 10, 2, -1},
+		{0, 10, 2, 0, 5, 10, 0, 6, 5, 0, 11, 6, 0, 8, 11, -1},
+		{5, 11, 6, 5, 3, 11, 5, 2, 3, 5, 10, 2, -1, -1, -1, -1},
+		{2, 8, 3, 2, 9, 8, 2, 5, 9, 2, 6, 5, -1, -1, -1, -1},
+		{0, 5, 9, 0, 6, 5, 0, 2, 6, -1, -1, -1, -1, -1, -1, -1},
+		{5, 2, 6, 5, 3, 2, 5, 8, 3, 5, 0, 8, 5, 1, 0, -1},
+		{1, 6, 5, 1, 2, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{3, 9, 8, 3, 5, 9, 3, 6, 5, 3, 10, 6, 3, 1, 10, -1},
+		{0, 5, 9, 0, 6, 5, 0, 10, 6, 0, 1, 10, -1, -1, -1, -1},
+		{0, 8, 3, 5, 10, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{5, 10, 6, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{5, 11, 10, 5, 7, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 5, 11, 10, 5, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 5, 11, 10, 5, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 9, 1, 3, 8, 5, 11, 10, 5, 7, 11, -1, -1, -1, -1},
+		{1, 11, 2, 1, 7, 11, 1, 5, 7, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 1, 11, 2, 1, 7, 11, 1, 5, 7, -1, -1, -1, -1},
+		{0, 11, 2, 0, 7, 11, 0, 5, 7, 0, 9, 5, -1, -1, -1, -1},
+		{2, 7, 11, 2, 5, 7, 2, 9, 5, 2, 8, 9, 2, 3, 8, -1},
+		{2, 7, 3, 2, 5, 7, 2, 10, 5, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 8, 0, 5, 7, 0, 10, 5, 0, 2, 10, -1, -1, -1, -1},
+		{0, 9, 1, 2, 7, 3, 2, 5, 7, 2, 10, 5, -1, -1, -1, -1},
+		{8, 5, 7, 8, 10, 5, 8, 2, 10, 8, 1, 2, 8, 9, 1, -1},
+		{1, 7, 3, 1, 5, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 8, 0, 5, 7, 0, 1, 5, -1, -1, -1, -1, -1, -1, -1},
+		{0, 7, 3, 0, 5, 7, 0, 9, 5, -1, -1, -1, -1, -1, -1, -1},
+		{5, 8, 9, 5, 7, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{4, 10, 5, 4, 11, 10, 4, 8, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 4, 0, 10, 5, 0, 11, 10, 0, 3, 11, -1, -1, -1, -1},
+		{0, 9, 1, 4, 10, 5, 4, 11, 10, 4, 8, 11, -1, -1, -1, -1},
+		{4, 10, 5, 4, 11, 10, 4, 3, 11, 4, 1, 3, 4, 9, 1, -1},
+		{1, 11, 2, 1, 8, 11, 1, 4, 8, 1, 5, 4, -1, -1, -1, -1},
+		{4, 1, 5, 4, 2, 1, 4, 11, 2, 4, 3, 11, 4, 0, 3, -1},
+		{2, 8, 11, 2, 4, 8, 2, 5, 4, 2, 9, 5, 2, 0, 9, -1},
+		{2, 3, 11, 4, 9, 5, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 8, 3, 2, 4, 8, 2, 5, 4, 2, 10, 5, -1, -1, -1, -1},
+		{0, 5, 4, 0, 10, 5, 0, 2, 10, -1, -1, -1, -1, -1, -1, -1},
+		{0, 9, 1, 2, 8, 3, 2, 4, 8, 2, 5, 4, 2, 10, 5, -1},
+		{4, 10, 5, 4, 2, 10, 4, 1, 2, 4, 9, 1, -1, -1, -1, -1},
+		{1, 8, 3, 1, 4, 8, 1, 5, 4, -1, -1, -1, -1, -1, -1, -1},
+		{0, 5, 4, 0, 1, 5, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{3, 4, 8, 3, 5, 4, 3, 9, 5, 3, 0, 9, -1, -1, -1, -1},
+		{4, 9, 5, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{4, 10, 9, 4, 11, 10, 4, 7, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 3, 8, 4, 10, 9, 4, 11, 10, 4, 7, 11, -1, -1, -1, -1},
+		{0, 10, 1, 0, 11, 10, 0, 7, 11, 0, 4, 7, -1, -1, -1, -1},
+		{1, 11, 10, 1, 7, 11, 1, 4, 7, 1, 8, 4, 1, 3, 8, -1},
+		{1, 11, 2, 1, 7, 11, 1, 4, 7, 1, 9, 4, -1, -1, -1, -1},
+		{0, 3, 8, 1, 11, 2, 1, 7, 11, 1, 4, 7, 1, 9, 4, -1},
+		{0, 11, 2, 0, 7, 11, 0, 4, 7, -1, -1, -1, -1, -1, -1, -1},
+		{2, 7, 11, 2, 4, 7, 2, 8, 4, 2, 3, 8, -1, -1, -1, -1},
+		{2, 7, 3, 2, 4, 7, 2, 9, 4, 2, 10, 9, -1, -1, -1, -1},
+		{7, 9, 4, 7, 10, 9, 7, 2, 10, 7, 0, 2, 7, 8, 0, -1},
+		{10, 3, 2, 10, 7, 3, 10, 4, 7, 10, 0, 4, 10, 1, 0, -1},
+		{1, 2, 10, 4, 7, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 7, 3, 1, 4, 7, 1, 9, 4, -1, -1, -1, -1, -1, -1, -1},
+		{7, 9, 4, 7, 1, 9, 7, 0, 1, 7, 8, 0, -1, -1, -1, -1},
+		{0, 7, 3, 0, 4, 7, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{4, 7, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{8, 10, 9, 8, 11, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 9, 0, 11, 10, 0, 3, 11, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 1, 0, 11, 10, 0, 8, 11, -1, -1, -1, -1, -1, -1, -1},
+		{1, 11, 10, 1, 3, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 11, 2, 1, 8, 11, 1, 9, 8, -1, -1, -1, -1, -1, -1, -1},
+		{9, 2, 1, 9, 11, 2, 9, 3, 11, 9, 0, 3, -1, -1, -1, -1},
+		{0, 11, 2, 0, 8, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 3, 11, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{2, 8, 3, 2, 9, 8, 2, 10, 9, -1, -1, -1, -1, -1, -1, -1},
+		{0, 10, 9, 0, 2, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{10, 3, 2, 10, 8, 3, 10, 0, 8, 10, 1, 0, -1, -1, -1, -1},
+		{1, 2, 10, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{1, 8, 3, 1, 9, 8, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 1, 9, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{0, 8, 3, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+		{-1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1, -1},
+	}
+)
+
+//	A scalar field sampled at the points of a regular 3-dimensional grid, from which `MarchingCubes` and
+//	`DualContour` extract the isosurface where the field equals an iso value. Values below the iso value count as
+//	inside, so normals point towards higher values (outwards for an `SDF`; negate fields such as metaballs that are
+//	higher inside). Fill it from a field callback with `NewScalarGrid`, or from existing samples with `Set`.
+type ScalarGrid struct {
+	//	The position of the sample at grid coordinates 0, 0, 0.
+	Origin Vec3
+
+	//	The distance between neighboring samples along each axis.
+	Spacing Vec3
+
+	//	The number of samples along each axis, at least 2 each.
+	Size [3]int
+
+	//	The samples, X varying fastest, then Y, then Z.
+	Values []float64
+
+	//	If not `nil`, the field that `Values` sample, used to locate surface crossings and normals more accurately.
+	Field SDF
+}
+
+//	Returns a new `*ScalarGrid` with `size` samples along each axis spanning `bounds`. If `field` is not `nil`, it
+//	becomes the `Field` and the grid is sampled from it; otherwise all `Values` are 0, to be filled in with `Set`.
+func NewScalarGrid(bounds *AABB, size [3]int, field SDF) (me *ScalarGrid) {
+	me = &ScalarGrid{Origin: bounds.Min, Size: size, Field: field}
+	for i := range me.Size {
+		me.Size[i] = imax(me.Size[i], 2)
+	}
+	me.Spacing.SetFromSub(&bounds.Max, &bounds.Min)
+	me.Spacing.X, me.Spacing.Y, me.Spacing.Z = me.Spacing.X/float64(me.Size[0]-1), me.Spacing.Y/float64(me.Size[1]-1), me.Spacing.Z/float64(me.Size[2]-1)
+	me.Values = make([]float64, me.Size[0]*me.Size[1]*me.Size[2])
+	if field != nil {
+		for z := 0; z < me.Size[2]; z++ {
+			for y := 0; y < me.Size[1]; y++ {
+				for x := 0; x < me.Size[0]; x++ {
+					me.Values[me.index(x, y, z)] = field(me.Position(x, y, z))
+				}
+			}
+		}
+	}
+	return
+}
+
+//	Returns the sample at grid coordinates `x`, `y`, `z`.
+func (me *ScalarGrid) At(x, y, z int) float64 {
+	return me.Values[me.index(x, y, z)]
+}
+
+//	Extracts the isosurface where the field equals `iso` by dual contouring: places one vertex per cell crossed by
+//	the surface where it best fits the tangent planes at the crossings on the cell's edges (clamped to the cell), and
+//	joins the 4 vertices around each crossed edge into a quad of 2 triangles. Unlike `MarchingCubes`, this preserves
+//	sharp edges and corners of the field. The surface is open where it leaves the grid.
+func (me *ScalarGrid) DualContour(iso float64) (mesh *Mesh) {
+	mesh = new(Mesh)
+	cellVerts := make([]int, len(me.Values))
+	var points, normals []Vec3
+	for z := 0; z < me.Size[2]-1; z++ {
+		for y := 0; y < me.Size[1]-1; y++ {
+			for x := 0; x < me.Size[0]-1; x++ {
+				cell := me.index(x, y, z)
+				cellVerts[cell], points, normals = -1, points[:0], normals[:0]
+				for _, corners := range marchingCubesEdgeCorners {
+					a, b := marchingCubesCorners[corners[0]], marchingCubesCorners[corners[1]]
+					if pos, ok := me.crossing(x+a[0], y+a[1], z+a[2], x+b[0], y+b[1], z+b[2], iso); ok {
+						points, normals = append(points, *pos), append(normals, *me.Gradient(pos).Normalized())
+					}
+				}
+				if len(points) > 0 {
+					min := me.Position(x, y, z)
+					max := min.Added(&me.Spacing)
+					pos := dualContourVertex(points, normals)
+					pos.Clamp(min, max)
+					cellVerts[cell] = len(mesh.Positions)
+					mesh.Positions = append(mesh.Positions, *pos)
+				}
+			}
+		}
+	}
+	//	a quad around every crossed edge that has cells on all 4 sides
+	var quad [4]int
+	for z := 0; z < me.Size[2]; z++ {
+		for y := 0; y < me.Size[1]; y++ {
+			for x := 0; x < me.Size[0]; x++ {
+				pos := [3]int{x, y, z}
+				for axis := 0; axis < 3; axis++ {
+					u, w := (axis+1)%3, (axis+2)%3
+					if pos[axis] >= me.Size[axis]-1 || pos[u] == 0 || pos[w] == 0 || pos[u] >= me.Size[u]-1 || pos[w] >= me.Size[w]-1 {
+						continue
+					}
+					end := pos
+					end[axis]++
+					below, endBelow := me.At(x, y, z) < iso, me.At(end[0], end[1], end[2]) < iso
+					if below == endBelow {
+						continue
+					}
+					//	the cells counter-clockwise around the edge when seen from its end
+					for i, offset := range [4][2]int{{1, 1}, {0, 1}, {0, 0}, {1, 0}} {
+						cell := pos
+						cell[u], cell[w] = cell[u]-offset[0], cell[w]-offset[1]
+						quad[i] = cellVerts[me.index(cell[0], cell[1], cell[2])]
+					}
+					if !below {
+						quad[1], quad[3] = quad[3], quad[1]
+					}
+					//	split along the shorter diagonal
+					p := mesh.Positions
+					if p[quad[0]].DistanceSq(&p[quad[2]]) <= p[quad[1]].DistanceSq(&p[quad[3]]) {
+						mesh.Indices = append(mesh.Indices, uint32(quad[0]), uint32(quad[1]), uint32(quad[2]), uint32(quad[0]), uint32(quad[2]), uint32(quad[3]))
+					} else {
+						mesh.Indices = append(mesh.Indices, uint32(quad[0]), uint32(quad[1]), uint32(quad[3]), uint32(quad[1]), uint32(quad[2]), uint32(quad[3]))
+					}
+				}
+			}
+		}
+	}
+	me.setNormals(mesh)
+	return
+}
+
+//	Returns the gradient of the field at `pos` by central differences: of `Field` if not `nil`, else of `Sample`.
+func (me *ScalarGrid) Gradient(pos *Vec3) (grad *Vec3) {
+	field, h := SDF(me.Sample), me.Spacing.Scaled(0.5)
+	if me.Field != nil {
+		field, h = me.Field, me.Spacing.Scaled(0.01)
+	}
+	dx, dy, dz := Vec3{h.X, 0, 0}, Vec3{0, h.Y, 0}, Vec3{0, 0, h.Z}
+	return &Vec3{
+		(field(pos.Added(&dx)) - field(pos.Sub(&dx))) / (2 * h.X),
+		(field(pos.Added(&dy)) - field(pos.Sub(&dy))) / (2 * h.Y),
+		(field(pos.Added(&dz)) - field(pos.Sub(&dz))) / (2 * h.Z),
+	}
+}
+
+//	Extracts the isosurface where the field equals `iso` by marching cubes: triangulates each cell according to
+//	which of its corners are below `iso`, with vertices interpolated linearly along the crossed edges and shared
+//	between neighboring cells. The surface is open where it leaves the grid.
+func (me *ScalarGrid) MarchingCubes(iso float64) (mesh *Mesh) {
+	mesh = new(Mesh)
+	edgeVerts := map[int]uint32{}
+	for z := 0; z < me.Size[2]-1; z++ {
+		for y := 0; y < me.Size[1]-1; y++ {
+			for x := 0; x < me.Size[0]-1; x++ {
+				index := 0
+				for i, c := range marchingCubesCorners {
+					if me.At(x+c[0], y+c[1], z+c[2]) < iso {
+						index |= 1 << uint(i)
+					}
+				}
+				if marchingCubesEdges[index] == 0 {
+					continue
+				}
+				for _, edge := range marchingCubesTriangles[index] {
+					if edge < 0 {
+						break
+					}
+					corners := marchingCubesEdgeCorners[edge]
+					a, b := marchingCubesCorners[corners[0]], marchingCubesCorners[corners[1]]
+					//	each grid edge is keyed by its lower sample and its axis
+					key := 3 * me.index(x+a[0], y+a[1], z+a[2])
+					for axis := range a {
+						if a[axis] != b[axis] {
+							key += axis
+						}
+					}
+					vert, ok := edgeVerts[key]
+					if !ok {
+						pos, _ := me.crossing(x+a[0], y+a[1], z+a[2], x+b[0], y+b[1], z+b[2], iso)
+						vert = uint32(len(mesh.Positions))
+						edgeVerts[key], mesh.Positions = vert, append(mesh.Positions, *pos)
+					}
+					mesh.Indices = append(mesh.Indices, vert)
+				}
+			}
+		}
+	}
+	me.setNormals(mesh)
+	return
+}
+
+//	Returns the position of the sample at grid coordinates `x`, `y`, `z`.
+func (me *ScalarGrid) Position(x, y, z int) *Vec3 {
+	return &Vec3{me.Origin.X + float64(x)*me.Spacing.X, me.Origin.Y + float64(y)*me.Spacing.Y, me.Origin.Z + float64(z)*me.Spacing.Z}
+}
+
+//	Returns the field at `pos` interpolated trilinearly between the samples, clamping `pos` to the grid.
+func (me *ScalarGrid) Sample(pos *Vec3) float64 {
+	var cell [3]int
+	var t [3]float64
+	for i, f := range [3]float64{(pos.X - me.Origin.X) / me.Spacing.X, (pos.Y - me.Origin.Y) / me.Spacing.Y, (pos.Z - me.Origin.Z) / me.Spacing.Z} {
+		f = Clamp(f, 0, float64(me.Size[i]-1))
+		if cell[i] = int(f); cell[i] > me.Size[i]-2 {
+			cell[i] = me.Size[i] - 2
+		}
+		t[i] = f - float64(cell[i])
+	}
+	val := 0.0
+	for _, c := range marchingCubesCorners {
+		weight := 1.0
+		for i := range c {
+			if c[i] == 0 {
+				weight *= 1 - t[i]
+			} else {
+				weight *= t[i]
+			}
+		}
+		val += weight * me.At(cell[0]+c[0], cell[1]+c[1], cell[2]+c[2])
+	}
+	return val
+}
+
+//	Sets the sample at grid coordinates `x`, `y`, `z` to `value`.
+func (me *ScalarGrid) Set(x, y, z int, value float64) {
+	me.Values[me.index(x, y, z)] = value
+}
+
+//	Returns the point where the field crosses `iso` on the grid edge from the sample at `x0`, `y0`, `z0` to the
+//	neighboring sample at `x1`, `y1`, `z1`, or `ok` is `false` if it does not cross there. The crossing is found on
+//	`Field` with `SolveBrent` if possible, else by interpolating linearly between the samples.
+func (me *ScalarGrid) crossing(x0, y0, z0, x1, y1, z1 int, iso float64) (pos *Vec3, ok bool) {
+	v0, v1 := me.At(x0, y0, z0), me.At(x1, y1, z1)
+	if ok = (v0 < iso) != (v1 < iso); ok {
+		p0, p1 := me.Position(x0, y0, z0), me.Position(x1, y1, z1)
+		t := (iso - v0) / (v1 - v0)
+		if me.Field != nil {
+			if root, found := SolveBrent(func(t float64) float64 { return me.Field(Vec3_Lerp(p0, p1, t)) - iso }, 0, 1, 1e-6, 0); found {
+				t = root
+			}
+		}
+		pos = Vec3_Lerp(p0, p1, t)
+	}
+	return
+}
+
+func (me *ScalarGrid) index(x, y, z int) int {
+	return x + me.Size[0]*(y+me.Size[1]*z)
+}
+
+func (me *ScalarGrid) setNormals(mesh *Mesh) {
+	mesh.Normals = make([]Vec3, len(mesh.Positions))
+	for i := range mesh.Positions {
+		mesh.Normals[i] = *me.Gradient(&mesh.Positions[i])
+		mesh.Normals[i].NormalizeSafe()
+	}
+}
+
+//	Returns the point minimizing the sum of squared distances to the planes through `points` with unit `normals`,
+//	solved by pseudo-inverse relative to the points' mass point, which it stays closest to along directions the
+//	planes leave (nearly) unconstrained as per `DualContourThreshold`.
+func dualContourVertex(points, normals []Vec3) (pos *Vec3) {
+	var mass, atb Vec3
+	var ata Mat3
+	for i := range points {
+		mass.Add(&points[i])
+	}
+	mass.Scale(1 / float64(len(points)))
+	for i := range points {
+		n := &normals[i]
+		d := n.DotSub(&points[i], &mass)
+		atb.Add(n.Scaled(d))
+		for c, nc := range [3]float64{n.X, n.Y, n.Z} {
+			ata[c*3], ata[c*3+1], ata[c*3+2] = ata[c*3]+n.X*nc, ata[c*3+1]+n.Y*nc, ata[c*3+2]+n.Z*nc
+		}
+	}
+	pos = &mass
+	vals, vecs := ata.SymmetricEigen()
+	for i, val := range [3]float64{vals.X, vals.Y, vals.Z} {
+		if val > DualContourThreshold*vals.X && val > 0 {
+			v := Vec3{vecs[i*3], vecs[i*3+1], vecs[i*3+2]}
+			pos.Add(v.Scaled(v.Dot(&atb) / val))
+		}
+	}
+	return
+}
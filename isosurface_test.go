@@ -0,0 +1,42 @@
+package unum
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMarchingCubesManifold(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	bounds := &AABB{Max: Vec3{1, 1, 1}}
+	for run := 0; run < 200; run++ {
+		grid := NewScalarGrid(bounds, [3]int{7, 7, 7}, nil)
+		for z := 1; z < 6; z++ {
+			for y := 1; y < 6; y++ {
+				for x := 1; x < 6; x++ {
+					grid.Set(x, y, z, rnd.Float64()*2-1)
+				}
+			}
+		}
+		//	the outermost samples stay above the iso value, closing the surface
+		for i := range grid.Values {
+			if grid.Values[i] == 0 {
+				grid.Values[i] = 1
+			}
+		}
+		mesh := grid.MarchingCubes(0)
+		edges := map[[2]uint32]int{}
+		for i := 0; i < len(mesh.Indices); i += 3 {
+			for k := 0; k < 3; k++ {
+				edges[[2]uint32{mesh.Indices[i+k], mesh.Indices[i+(k+1)%3]}]++
+			}
+		}
+		for edge, num := range edges {
+			if num != 1 || edges[[2]uint32{edge[1], edge[0]}] != 1 {
+				t.Fatalf("run %d: edge %v used %d times, its reverse %d times", run, edge, num, edges[[2]uint32{edge[1], edge[0]}])
+			}
+		}
+		if _, ok := NewHalfEdgeMesh(mesh.Positions, mesh.Indices); !ok {
+			t.Fatalf("run %d: not a manifold mesh", run)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package unum
+
+import (
+	"container/heap"
+	"math"
+)
+
+//	The maximum number of points in a k-d tree leaf, which are searched by brute force.
+var KdTreeLeafSize = 8
+
+//	Represents a static k-d tree over a set of `Vec2` points, for nearest-neighbor and radius queries.
+//	All query results are indices into the points the tree was built from.
+type KdTree2 struct {
+	tree kdTree
+}
+
+//	Returns a new `*KdTree2` over `points` (which are copied), split at the median of the widest axis at each level.
+func NewKdTree2(points []Vec2) (me *KdTree2) {
+	me = new(KdTree2)
+	pts := make([][3]float64, len(points))
+	for i := range points {
+		pts[i] = [3]float64{points[i].X, points[i].Y}
+	}
+	me.tree.build(2, pts)
+	return
+}
+
+//	Returns the index of the point nearest to `point` and its squared distance, or -1 if the tree is empty.
+func (me *KdTree2) Nearest(point *Vec2) (index int, sqDist float64) {
+	return me.tree.nearest([3]float64{point.X, point.Y}, 0)
+}
+
+//	Returns the index of a point whose distance to `point` is at most `1 + eps` times that of the nearest point,
+//	and its squared distance. Larger `eps` permit the search to skip more of the tree.
+func (me *KdTree2) NearestApprox(point *Vec2, eps float64) (index int, sqDist float64) {
+	return me.tree.nearest([3]float64{point.X, point.Y}, eps)
+}
+
+//	Returns the indices of the (at most) `k` points nearest to `point`, nearest first.
+func (me *KdTree2) NearestK(point *Vec2, k int) []int {
+	return me.tree.nearestK([3]float64{point.X, point.Y}, k)
+}
+
+//	Returns the indices of all points within `radius` of `point`, in no particular order.
+func (me *KdTree2) Radius(point *Vec2, radius float64) []int {
+	return me.tree.radius([3]float64{point.X, point.Y}, radius)
+}
+
+//	Represents a static k-d tree over a set of `Vec3` points, for nearest-neighbor and radius queries.
+//	All query results are indices into the points the tree was built from.
+type KdTree3 struct {
+	tree kdTree
+}
+
+//	Returns a new `*KdTree3` over `points` (which are copied), split at the median of the widest axis at each level.
+func NewKdTree3(points []Vec3) (me *KdTree3) {
+	me = new(KdTree3)
+	pts := make([][3]float64, len(points))
+	for i := range points {
+		pts[i] = [3]float64{points[i].X, points[i].Y, points[i].Z}
+	}
+	me.tree.build(3, pts)
+	return
+}
+
+//	Returns the index of the point nearest to `point` and its squared distance, or -1 if the tree is empty.
+func (me *KdTree3) Nearest(point *Vec3) (index int, sqDist float64) {
+	return me.tree.nearest([3]float64{point.X, point.Y, point.Z}, 0)
+}
+
+//	Returns the index of a point whose distance to `point` is at most `1 + eps` times that of the nearest point,
+//	and its squared distance. Larger `eps` permit the search to skip more of the tree.
+func (me *KdTree3) NearestApprox(point *Vec3, eps float64) (index int, sqDist float64) {
+	return me.tree.nearest([3]float64{point.X, point.Y, point.Z}, eps)
+}
+
+//	Returns the indices of the (at most) `k` points nearest to `point`, nearest first.
+func (me *KdTree3) NearestK(point *Vec3, k int) []int {
+	return me.tree.nearestK([3]float64{point.X, point.Y, point.Z}, k)
+}
+
+//	Returns the indices of all points within `radius` of `point`, in no particular order.
+func (me *KdTree3) Radius(point *Vec3, radius float64) []int {
+	return me.tree.radius([3]float64{point.X, point.Y, point.Z}, radius)
+}
+
+//	The dimension-agnostic implementation of `KdTree2` and `KdTree3`: an implicit balanced tree over `order`,
+//	where each range's median element is its node, split along `axis[median]`.
+type kdTree struct {
+	dims, leafSize int
+	pts            [][3]float64
+	order          []int
+	axis           []int8
+}
+
+func (me *kdTree) build(dims int, pts [][3]float64) {
+	me.dims, me.pts, me.leafSize = dims, pts, KdTreeLeafSize
+	if me.leafSize < 1 {
+		me.leafSize = 1
+	}
+	me.order, me.axis = make([]int, len(pts)), make([]int8, len(pts))
+	for i := range me.order {
+		me.order[i] = i
+	}
+	me.split(0, len(pts))
+}
+
+func (me *kdTree) split(lo, hi int) {
+	if hi-lo <= me.leafSize {
+		return
+	}
+	var min, max [3]float64
+	for a := 0; a < me.dims; a++ {
+		min[a], max[a] = math.Inf(1), math.Inf(-1)
+	}
+	for _, i := range me.order[lo:hi] {
+		for a := 0; a < me.dims; a++ {
+			min[a], max[a] = math.Min(min[a], me.pts[i][a]), math.Max(max[a], me.pts[i][a])
+		}
+	}
+	axis := 0
+	for a := 1; a < me.dims; a++ {
+		if max[a]-min[a] > max[axis]-min[axis] {
+			axis = a
+		}
+	}
+	mid := (lo + hi) / 2
+	me.selectNth(lo, hi, mid, axis)
+	me.axis[mid] = int8(axis)
+	me.split(lo, mid)
+	me.split(mid+1, hi)
+}
+
+//	Partially sorts `order[lo:hi]` along `axis` (quickselect) so that `order[nth]` is in its sorted position.
+func (me *kdTree) selectNth(lo, hi, nth, axis int) {
+	key := func(i int) float64 { return me.pts[me.order[i]][axis] }
+	for hi-lo > 1 {
+		//	three-way partition around the median of 3 samples, so that runs of equal keys cost no extra passes
+		a, b, c := key(lo), key((lo+hi)/2), key(hi-1)
+		pivot := math.Max(math.Min(a, b), math.Min(math.Max(a, b), c))
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			if k := key(i); k < pivot {
+				me.order[lt], me.order[i] = me.order[i], me.order[lt]
+				lt, i = lt+1, i+1
+			} else if k > pivot {
+				gt--
+				me.order[gt], me.order[i] = me.order[i], me.order[gt]
+			} else {
+				i++
+			}
+		}
+		if nth < lt {
+			hi = lt
+		} else if nth >= gt {
+			lo = gt
+		} else {
+			return
+		}
+	}
+}
+
+func (me *kdTree) sqDist(p *[3]float64, i int) (sq float64) {
+	for a := 0; a < me.dims; a++ {
+		d := p[a] - me.pts[i][a]
+		sq += d * d
+	}
+	return
+}
+
+//	Visits `order[lo:hi]` nearest-side first, calling `leaf` for each point and pruning the far side of each
+//	split plane whose squared distance exceeds `bound()`.
+func (me *kdTree) search(p *[3]float64, lo, hi int, leaf func(i int, sqDist float64), bound func() float64) {
+	if hi-lo <= me.leafSize {
+		for _, i := range me.order[lo:hi] {
+			leaf(i, me.sqDist(p, i))
+		}
+		return
+	}
+	mid := (lo + hi) / 2
+	i := me.order[mid]
+	d := p[me.axis[mid]] - me.pts[i][me.axis[mid]]
+	nearLo, nearHi, farLo, farHi := lo, mid, mid+1, hi
+	if d >= 0 {
+		nearLo, nearHi, farLo, farHi = mid+1, hi, lo, mid
+	}
+	me.search(p, nearLo, nearHi, leaf, bound)
+	leaf(i, me.sqDist(p, i))
+	if d*d <= bound() {
+		me.search(p, farLo, farHi, leaf, bound)
+	}
+}
+
+func (me *kdTree) nearest(p [3]float64, eps float64) (index int, sqDist float64) {
+	index, sqDist = -1, math.Inf(1)
+	shrink := 1 / ((1 + eps) * (1 + eps))
+	me.search(&p, 0, len(me.order), func(i int, sq float64) {
+		if sq < sqDist {
+			index, sqDist = i, sq
+		}
+	}, func() float64 { return sqDist * shrink })
+	return
+}
+
+func (me *kdTree) nearestK(p [3]float64, k int) (indices []int) {
+	if k <= 0 {
+		return
+	}
+	var best nearHeap
+	me.search(&p, 0, len(me.order), func(i int, sq float64) {
+		if best.Len() < k {
+			heap.Push(&best, nearItem{i, sq})
+		} else if sq < best[0].sqDist {
+			best[0] = nearItem{i, sq}
+			heap.Fix(&best, 0)
+		}
+	}, func() float64 {
+		if best.Len() < k {
+			return math.Inf(1)
+		}
+		return best[0].sqDist
+	})
+	indices = make([]int, best.Len())
+	for i := len(indices) - 1; i >= 0; i-- {
+		indices[i] = heap.Pop(&best).(nearItem).id
+	}
+	return
+}
+
+func (me *kdTree) radius(p [3]float64, radius float64) (indices []int) {
+	r2 := radius * radius
+	me.search(&p, 0, len(me.order), func(i int, sq float64) {
+		if sq <= r2 {
+			indices = append(indices, i)
+		}
+	}, func() float64 { return r2 })
+	return
+}
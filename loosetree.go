@@ -0,0 +1,378 @@
+package unum
+
+import (
+	"container/heap"
+	"math"
+)
+
+//	Represents a loose octree: a sparse, adaptive spatial index over items bounded by spheres.
+//
+//	Each item is stored in the deepest node whose cell contains its center and whose "loose" bounds
+//	(the cell grown to twice its size) contain its whole sphere, so moving items rarely change nodes
+//	and are never split across several. Nodes are created on demand and pruned when empty.
+//	Items outside the root cell are kept in the root. An `Octree` is not safe for concurrent modification.
+type Octree struct {
+	tree looseTree
+}
+
+//	Returns a new `*Octree` whose root cell is the cube at `center` with `halfSize`, subdivided at most `maxDepth` times.
+func NewOctree(center *Vec3, halfSize float64, maxDepth int) (me *Octree) {
+	me = new(Octree)
+	me.tree.init(3, [3]float64{center.X, center.Y, center.Z}, halfSize, maxDepth)
+	return
+}
+
+//	Adds an item with the sphere at `pos` with `radius` as its bounds and the associated `item` (which may be `nil`),
+//	and returns its ID.
+func (me *Octree) Insert(pos *Vec3, radius float64, item interface{}) int {
+	return me.tree.insert([3]float64{pos.X, pos.Y, pos.Z}, radius, item)
+}
+
+//	Returns the item associated with the specified `id`.
+func (me *Octree) Item(id int) interface{} {
+	return me.tree.items[id].item
+}
+
+//	Updates the bounding sphere of the item with the specified `id` to be at `pos` with `radius`.
+func (me *Octree) Move(id int, pos *Vec3, radius float64) {
+	me.tree.move(id, [3]float64{pos.X, pos.Y, pos.Z}, radius)
+}
+
+//	Returns the IDs of the (at most) `k` items whose centers are nearest to `point`, nearest first.
+func (me *Octree) Nearest(point *Vec3, k int) []int {
+	return me.tree.nearest([3]float64{point.X, point.Y, point.Z}, k)
+}
+
+//	Returns the center of the bounding sphere of the item with the specified `id`.
+func (me *Octree) Position(id int) *Vec3 {
+	p := &me.tree.items[id].pos
+	return &Vec3{p[0], p[1], p[2]}
+}
+
+//	Calls `visit` with the ID of every item whose bounding sphere overlaps `aabb`, until `visit` returns `false`.
+func (me *Octree) QueryAABB(aabb *AABB, visit func(id int) bool) {
+	me.tree.queryBox([3]float64{aabb.Min.X, aabb.Min.Y, aabb.Min.Z}, [3]float64{aabb.Max.X, aabb.Max.Y, aabb.Max.Z}, visit)
+}
+
+//	Calls `visit` with the ID of every item whose bounding sphere overlaps the sphere at `center` with `radius`,
+//	until `visit` returns `false`.
+func (me *Octree) QueryRadius(center *Vec3, radius float64, visit func(id int) bool) {
+	me.tree.queryRadius([3]float64{center.X, center.Y, center.Z}, radius, visit)
+}
+
+//	Removes the item with the specified `id`, which may then be reused by `Insert`.
+func (me *Octree) Remove(id int) {
+	me.tree.remove(id)
+}
+
+//	Represents a loose quadtree: the 2D counterpart of `Octree`, over items bounded by circles.
+type Quadtree struct {
+	tree looseTree
+}
+
+//	Returns a new `*Quadtree` whose root cell is the square at `center` with `halfSize`, subdivided at most `maxDepth` times.
+func NewQuadtree(center *Vec2, halfSize float64, maxDepth int) (me *Quadtree) {
+	me = new(Quadtree)
+	me.tree.init(2, [3]float64{center.X, center.Y}, halfSize, maxDepth)
+	return
+}
+
+//	Adds an item with the circle at `pos` with `radius` as its bounds and the associated `item` (which may be `nil`),
+//	and returns its ID.
+func (me *Quadtree) Insert(pos *Vec2, radius float64, item interface{}) int {
+	return me.tree.insert([3]float64{pos.X, pos.Y}, radius, item)
+}
+
+//	Returns the item associated with the specified `id`.
+func (me *Quadtree) Item(id int) interface{} {
+	return me.tree.items[id].item
+}
+
+//	Updates the bounding circle of the item with the specified `id` to be at `pos` with `radius`.
+func (me *Quadtree) Move(id int, pos *Vec2, radius float64) {
+	me.tree.move(id, [3]float64{pos.X, pos.Y}, radius)
+}
+
+//	Returns the IDs of the (at most) `k` items whose centers are nearest to `point`, nearest first.
+func (me *Quadtree) Nearest(point *Vec2, k int) []int {
+	return me.tree.nearest([3]float64{point.X, point.Y}, k)
+}
+
+//	Returns the center of the bounding circle of the item with the specified `id`.
+func (me *Quadtree) Position(id int) *Vec2 {
+	p := &me.tree.items[id].pos
+	return &Vec2{p[0], p[1]}
+}
+
+//	Calls `visit` with the ID of every item whose bounding circle overlaps the circle at `center` with `radius`,
+//	until `visit` returns `false`.
+func (me *Quadtree) QueryRadius(center *Vec2, radius float64, visit func(id int) bool) {
+	me.tree.queryRadius([3]float64{center.X, center.Y}, radius, visit)
+}
+
+//	Calls `visit` with the ID of every item whose bounding circle overlaps the axis-aligned rectangle from `min` to `max`,
+//	until `visit` returns `false`.
+func (me *Quadtree) QueryRect(min, max *Vec2, visit func(id int) bool) {
+	me.tree.queryBox([3]float64{min.X, min.Y}, [3]float64{max.X, max.Y}, visit)
+}
+
+//	Removes the item with the specified `id`, which may then be reused by `Insert`.
+func (me *Quadtree) Remove(id int) {
+	me.tree.remove(id)
+}
+
+//	The dimension-agnostic implementation of `Octree` (3 dimensions) and `Quadtree` (2 dimensions).
+type looseTree struct {
+	dims, maxDepth int
+	root           *looseNode
+	items          []looseItem
+	free           []int
+}
+
+type looseNode struct {
+	center   [3]float64
+	half     float64
+	depth    int
+	parent   *looseNode
+	children [8]*looseNode
+	numKids  int
+	items    []int
+}
+
+type looseItem struct {
+	pos    [3]float64
+	radius float64
+	node   *looseNode
+	item   interface{}
+}
+
+func (me *looseTree) init(dims int, center [3]float64, half float64, maxDepth int) {
+	me.dims, me.maxDepth = dims, maxDepth
+	me.root = &looseNode{center: center, half: half}
+}
+
+//	Returns the node that should hold an item at `pos` with `radius`, creating it if needed.
+func (me *looseTree) target(pos [3]float64, radius float64) (node *looseNode) {
+	node = me.root
+	for a := 0; a < me.dims; a++ {
+		if math.Abs(pos[a]-node.center[a]) > node.half {
+			return
+		}
+	}
+	for node.depth < me.maxDepth && radius <= node.half*0.5 {
+		child := 0
+		for a := 0; a < me.dims; a++ {
+			if pos[a] >= node.center[a] {
+				child |= 1 << uint(a)
+			}
+		}
+		if node.children[child] == nil {
+			kid := &looseNode{half: node.half * 0.5, depth: node.depth + 1, parent: node}
+			for a := 0; a < me.dims; a++ {
+				if kid.center[a] = node.center[a] - kid.half; child&(1<<uint(a)) != 0 {
+					kid.center[a] = node.center[a] + kid.half
+				}
+			}
+			node.children[child], node.numKids = kid, node.numKids+1
+		}
+		node = node.children[child]
+	}
+	return
+}
+
+func (me *looseTree) insert(pos [3]float64, radius float64, item interface{}) (id int) {
+	if l := len(me.free); l > 0 {
+		id, me.free = me.free[l-1], me.free[:l-1]
+	} else {
+		id = len(me.items)
+		me.items = append(me.items, looseItem{})
+	}
+	me.items[id] = looseItem{pos: pos, radius: radius, item: item}
+	me.place(id)
+	return
+}
+
+func (me *looseTree) place(id int) {
+	it := &me.items[id]
+	it.node = me.target(it.pos, it.radius)
+	it.node.items = append(it.node.items, id)
+}
+
+func (me *looseTree) unplace(id int) {
+	node := me.items[id].node
+	for i, other := range node.items {
+		if other == id {
+			last := len(node.items) - 1
+			node.items[i], node.items = node.items[last], node.items[:last]
+			break
+		}
+	}
+	//	prune empty leaves upwards
+	for node.parent != nil && node.numKids == 0 && len(node.items) == 0 {
+		parent := node.parent
+		for c := range parent.children {
+			if parent.children[c] == node {
+				parent.children[c], parent.numKids = nil, parent.numKids-1
+			}
+		}
+		node = parent
+	}
+}
+
+func (me *looseTree) move(id int, pos [3]float64, radius float64) {
+	it := &me.items[id]
+	it.pos, it.radius = pos, radius
+	//	stay put while the center remains in the node's cell and the node remains the deepest fitting one
+	node, fits := it.node, true
+	for a := 0; a < me.dims && fits; a++ {
+		fits = math.Abs(pos[a]-node.center[a]) <= node.half
+	}
+	if fits && radius <= node.half && (node.depth == me.maxDepth || radius > node.half*0.5) {
+		return
+	}
+	me.unplace(id)
+	me.place(id)
+}
+
+func (me *looseTree) remove(id int) {
+	me.unplace(id)
+	me.items[id] = looseItem{}
+	me.free = append(me.free, id)
+}
+
+func (me *looseTree) queryBox(min, max [3]float64, visit func(id int) bool) {
+	me.query(func(center [3]float64, half float64) bool {
+		for a := 0; a < me.dims; a++ {
+			if center[a]-half > max[a] || center[a]+half < min[a] {
+				return false
+			}
+		}
+		return true
+	}, func(it *looseItem) bool {
+		sq := 0.0
+		for a := 0; a < me.dims; a++ {
+			if d := math.Max(min[a]-it.pos[a], it.pos[a]-max[a]); d > 0 {
+				sq += d * d
+			}
+		}
+		return sq <= it.radius*it.radius
+	}, visit)
+}
+
+func (me *looseTree) queryRadius(center [3]float64, radius float64, visit func(id int) bool) {
+	me.query(func(c [3]float64, half float64) bool {
+		sq := 0.0
+		for a := 0; a < me.dims; a++ {
+			if d := math.Abs(center[a]-c[a]) - half; d > 0 {
+				sq += d * d
+			}
+		}
+		return sq <= radius*radius
+	}, func(it *looseItem) bool {
+		r := radius + it.radius
+		return me.sqDist(&center, &it.pos) <= r*r
+	}, visit)
+}
+
+//	Visits all items passing `testItem` in all nodes whose loose bounds (the root's are unbounded) pass `testNode`.
+func (me *looseTree) query(testNode func(center [3]float64, half float64) bool, testItem func(*looseItem) bool, visit func(id int) bool) {
+	stack := []*looseNode{me.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, id := range node.items {
+			if testItem(&me.items[id]) && !visit(id) {
+				return
+			}
+		}
+		for _, kid := range node.children {
+			if kid != nil && testNode(kid.center, kid.half*2) {
+				stack = append(stack, kid)
+			}
+		}
+	}
+}
+
+func (me *looseTree) sqDist(a, b *[3]float64) (sq float64) {
+	for i := 0; i < me.dims; i++ {
+		d := a[i] - b[i]
+		sq += d * d
+	}
+	return
+}
+
+func (me *looseTree) nearest(point [3]float64, k int) (ids []int) {
+	if k <= 0 {
+		return
+	}
+	//	best-first: expand nodes by increasing distance from `point` to their cells, which contain all their item centers
+	var nodes looseNodeHeap
+	var best nearHeap
+	heap.Push(&nodes, looseNodeDist{me.root, 0})
+	for nodes.Len() > 0 {
+		nd := heap.Pop(&nodes).(looseNodeDist)
+		if best.Len() == k && nd.sqDist > best[0].sqDist {
+			break
+		}
+		for _, id := range nd.node.items {
+			if d := me.sqDist(&point, &me.items[id].pos); best.Len() < k {
+				heap.Push(&best, nearItem{id, d})
+			} else if d < best[0].sqDist {
+				best[0] = nearItem{id, d}
+				heap.Fix(&best, 0)
+			}
+		}
+		for _, kid := range nd.node.children {
+			if kid != nil {
+				sq := 0.0
+				for a := 0; a < me.dims; a++ {
+					if d := math.Abs(point[a]-kid.center[a]) - kid.half; d > 0 {
+						sq += d * d
+					}
+				}
+				heap.Push(&nodes, looseNodeDist{kid, sq})
+			}
+		}
+	}
+	ids = make([]int, best.Len())
+	for i := len(ids) - 1; i >= 0; i-- {
+		ids[i] = heap.Pop(&best).(nearItem).id
+	}
+	return
+}
+
+type looseNodeDist struct {
+	node   *looseNode
+	sqDist float64
+}
+
+//	A min-heap of nodes by distance.
+type looseNodeHeap []looseNodeDist
+
+func (me looseNodeHeap) Len() int            { return len(me) }
+func (me looseNodeHeap) Less(i, j int) bool  { return me[i].sqDist < me[j].sqDist }
+func (me looseNodeHeap) Swap(i, j int)       { me[i], me[j] = me[j], me[i] }
+func (me *looseNodeHeap) Push(x interface{}) { *me = append(*me, x.(looseNodeDist)) }
+
+func (me *looseNodeHeap) Pop() (x interface{}) {
+	x, *me = (*me)[len(*me)-1], (*me)[:len(*me)-1]
+	return
+}
+
+type nearItem struct {
+	id     int
+	sqDist float64
+}
+
+//	A max-heap of the nearest items found so far, farthest on top.
+type nearHeap []nearItem
+
+func (me nearHeap) Len() int            { return len(me) }
+func (me nearHeap) Less(i, j int) bool  { return me[i].sqDist > me[j].sqDist }
+func (me nearHeap) Swap(i, j int)       { me[i], me[j] = me[j], me[i] }
+func (me *nearHeap) Push(x interface{}) { *me = append(*me, x.(nearItem)) }
+
+func (me *nearHeap) Pop() (x interface{}) {
+	x, *me = (*me)[len(*me)-1], (*me)[:len(*me)-1]
+	return
+}
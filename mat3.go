@@ -1,5 +1,9 @@
 package unum
 
+import (
+	"math"
+)
+
 //	Represents a 3x3 matrix.
 type Mat3 [9]float64
 
@@ -14,11 +18,157 @@ func init() {
 	Mat3Identity[2], Mat3Identity[5], Mat3Identity[8] = 0, 0, 1
 }
 
+//	Adds `mat` to `me`.
+func (me *Mat3) Add(mat *Mat3) {
+	me[0], me[3], me[6] = me[0]+mat[0], me[3]+mat[3], me[6]+mat[6]
+	me[1], me[4], me[7] = me[1]+mat[1], me[4]+mat[4], me[7]+mat[7]
+	me[2], me[5], me[8] = me[2]+mat[2], me[5]+mat[5], me[8]+mat[8]
+}
+
+//	Zeroes all cells in `me`.
+func (me *Mat3) Clear() {
+	*me = Mat3{}
+}
+
+//	Returns the determinant of `me`.
+func (me *Mat3) Determinant() float64 {
+	return me[0]*(me[4]*me[8]-me[7]*me[5]) - me[3]*(me[1]*me[8]-me[7]*me[2]) + me[6]*(me[1]*me[5]-me[4]*me[2])
+}
+
 //	Sets this 3x3 matrix to `Mat3Identity`.
 func (me *Mat3) Identity() {
 	*me = Mat3Identity
 }
 
+//	Multiplies all cells in `me` with `v`.
+func (me *Mat3) Mult1(v float64) {
+	me[0], me[3], me[6] = me[0]*v, me[3]*v, me[6]*v
+	me[1], me[4], me[7] = me[1]*v, me[4]*v, me[7]*v
+	me[2], me[5], me[8] = me[2]*v, me[5]*v, me[8]*v
+}
+
+//	Sets `me` to a transformation matrix representing "scale by `vec`".
+func (me *Mat3) Scaling(vec *Vec3) {
+	me[0], me[3], me[6] = vec.X, 0, 0
+	me[1], me[4], me[7] = 0, vec.Y, 0
+	me[2], me[5], me[8] = 0, 0, vec.Z
+}
+
+//	Sets `me` to the inverse of `mat`, unless `mat` is singular (in which case `me` is left unchanged and `false` is returned).
+func (me *Mat3) SetFromInverseOf(mat *Mat3) (ok bool) {
+	b0 := mat[4]*mat[8] - mat[7]*mat[5]
+	b1 := mat[7]*mat[2] - mat[1]*mat[8]
+	b2 := mat[1]*mat[5] - mat[4]*mat[2]
+	d := mat[0]*b0 + mat[3]*b1 + mat[6]*b2
+	if ok = d != 0; ok {
+		d = 1 / d
+		m0, m3, m6 := b0*d, (mat[6]*mat[5]-mat[3]*mat[8])*d, (mat[3]*mat[7]-mat[6]*mat[4])*d
+		m1, m4, m7 := b1*d, (mat[0]*mat[8]-mat[6]*mat[2])*d, (mat[6]*mat[1]-mat[0]*mat[7])*d
+		m2, m5, m8 := b2*d, (mat[3]*mat[2]-mat[0]*mat[5])*d, (mat[0]*mat[4]-mat[3]*mat[1])*d
+		me[0], me[3], me[6] = m0, m3, m6
+		me[1], me[4], me[7] = m1, m4, m7
+		me[2], me[5], me[8] = m2, m5, m8
+	}
+	return
+}
+
+//	Sets `me` to the upper-left 3x3 portion (rotation and scaling) of `mat`.
+func (me *Mat3) SetFromMat4(mat *Mat4) {
+	me[0], me[3], me[6] = mat[0], mat[4], mat[8]
+	me[1], me[4], me[7] = mat[1], mat[5], mat[9]
+	me[2], me[5], me[8] = mat[2], mat[6], mat[10]
+}
+
+//	Sets `me` to the result of multiplying `one` times `two`.
+func (me *Mat3) SetFromMult3(one, two *Mat3) {
+	var m Mat3
+	m[0], m[3], m[6] = one[0]*two[0]+one[3]*two[1]+one[6]*two[2], one[0]*two[3]+one[3]*two[4]+one[6]*two[5], one[0]*two[6]+one[3]*two[7]+one[6]*two[8]
+	m[1], m[4], m[7] = one[1]*two[0]+one[4]*two[1]+one[7]*two[2], one[1]*two[3]+one[4]*two[4]+one[7]*two[5], one[1]*two[6]+one[4]*two[7]+one[7]*two[8]
+	m[2], m[5], m[8] = one[2]*two[0]+one[5]*two[1]+one[8]*two[2], one[2]*two[3]+one[5]*two[4]+one[8]*two[5], one[2]*two[6]+one[5]*two[7]+one[8]*two[8]
+	*me = m
+}
+
+//	Sets `me` to the rotation matrix represented by the unit quaternion `q`.
+func (me *Mat3) SetFromQuat(q *Quat) {
+	xx, yy, zz := q.X*q.X, q.Y*q.Y, q.Z*q.Z
+	xy, xz, yz := q.X*q.Y, q.X*q.Z, q.Y*q.Z
+	wx, wy, wz := q.W*q.X, q.W*q.Y, q.W*q.Z
+	me[0], me[3], me[6] = 1-2*(yy+zz), 2*(xy-wz), 2*(xz+wy)
+	me[1], me[4], me[7] = 2*(xy+wz), 1-2*(xx+zz), 2*(yz-wx)
+	me[2], me[5], me[8] = 2*(xz-wy), 2*(yz+wx), 1-2*(xx+yy)
+}
+
+//	Sets `me` to the transpose of `mat`.
+func (me *Mat3) SetFromTransposeOf(mat *Mat3) {
+	m := *mat
+	me[0], me[3], me[6] = m[0], m[1], m[2]
+	me[1], me[4], me[7] = m[3], m[4], m[5]
+	me[2], me[5], me[8] = m[6], m[7], m[8]
+}
+
+//	Sets `me` to `rot * mat * transpose(rot)`, ie. `mat` transformed into the basis described by the rotation matrix `rot`.
+func (me *Mat3) SetFromRotated(rot, mat *Mat3) {
+	var rt Mat3
+	rt.SetFromTransposeOf(rot)
+	me.SetFromMult3(mat, &rt)
+	me.SetFromMult3(rot, me)
+}
+
+//	Computes the eigenvalues and eigenvectors of `me`, which must be symmetric, via cyclic Jacobi rotations.
+//
+//	The eigenvalues are returned in descending order in `vals`; the column `i` of `vecs` holds the unit eigenvector for the `i`th eigenvalue.
+func (me *Mat3) SymmetricEigen() (vals *Vec3, vecs *Mat3) {
+	var a, v [3][3]float64
+	for c := 0; c < 3; c++ {
+		for r := 0; r < 3; r++ {
+			a[r][c] = me[c*3+r]
+		}
+		v[c][c] = 1
+	}
+	for sweep := 0; sweep < 50; sweep++ {
+		off := a[0][1]*a[0][1] + a[0][2]*a[0][2] + a[1][2]*a[1][2]
+		if diag := a[0][0]*a[0][0] + a[1][1]*a[1][1] + a[2][2]*a[2][2]; off <= Epsilon*Epsilon*diag || off == 0 {
+			break
+		}
+		for p := 0; p < 2; p++ {
+			for q := p + 1; q < 3; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := 0; k < 3; k++ {
+					a[k][p], a[k][q] = c*a[k][p]-s*a[k][q], s*a[k][p]+c*a[k][q]
+				}
+				for k := 0; k < 3; k++ {
+					a[p][k], a[q][k] = c*a[p][k]-s*a[q][k], s*a[p][k]+c*a[q][k]
+				}
+				for k := 0; k < 3; k++ {
+					v[k][p], v[k][q] = c*v[k][p]-s*v[k][q], s*v[k][p]+c*v[k][q]
+				}
+			}
+		}
+	}
+	order := [3]int{0, 1, 2}
+	for i := 0; i < 2; i++ {
+		for j := i + 1; j < 3; j++ {
+			if a[order[j]][order[j]] > a[order[i]][order[i]] {
+				order[i], order[j] = order[j], order[i]
+			}
+		}
+	}
+	vals, vecs = &Vec3{a[order[0]][order[0]], a[order[1]][order[1]], a[order[2]][order[2]]}, new(Mat3)
+	for c, o := range order {
+		vecs[c*3], vecs[c*3+1], vecs[c*3+2] = v[0][o], v[1][o], v[2][o]
+	}
+	return
+}
+
 //	Transposes this 3x3 matrix.
 func (me *Mat3) Transpose() {
 	// a01, a02, a12 := me[1], me[2], me[5]
@@ -31,6 +181,13 @@ func (me *Mat3) Transpose() {
 	me[1], me[2], me[3], me[5], me[6], me[7] = me[3], me[6], me[1], me[7], me[2], me[5]
 }
 
+//	Returns the transpose of `me`.
+func (me *Mat3) Transposed() (mat *Mat3) {
+	mat = new(Mat3)
+	mat.SetFromTransposeOf(me)
+	return
+}
+
 //	Calls the `Identity` method on all specified `mats`.
 func Mat3Identities(mats ...*Mat3) {
 	for _, mat := range mats {
@@ -0,0 +1,321 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Specifies how `Mesh.ComputeNormals` and `Mesh.SplitHardEdges` weight the normals of the triangles around a vertex.
+type MeshNormalWeighting int
+
+const (
+	//	Weights each triangle by its area, favoring large faces.
+	MeshWeightArea MeshNormalWeighting = iota
+
+	//	Weights each triangle by its interior angle at the vertex, independent of tessellation.
+	MeshWeightAngle
+
+	//	Weights each triangle by the product of its area and its interior angle at the vertex.
+	MeshWeightAreaAngle
+)
+
+//	Represents an indexed triangle mesh. All per-vertex attributes are optional (`nil` or empty),
+//	but if present must have one entry per `Positions` entry.
+type Mesh struct {
+	//	Vertex positions.
+	Positions []Vec3
+
+	//	Vertex normals, of unit length.
+	Normals []Vec3
+
+	//	Vertex texture coordinates.
+	UVs []Vec2
+
+	//	Vertex tangents, of unit length in `X`, `Y`, `Z`, with the handedness of the tangent frame in `W`
+	//	(1 or -1), so that the bitangent is `W * Cross(normal, tangent)`.
+	Tangents []Vec4
+
+	//	Every 3 consecutive entries index the corners of a triangle, counter-clockwise when viewed from its front.
+	Indices []uint32
+}
+
+//	Returns the `AABB` tightly enclosing all `Positions`.
+func (me *Mesh) Bounds() *AABB {
+	return NewAABB(me.Positions...)
+}
+
+//	Returns a deep copy of `me`.
+func (me *Mesh) Clone() (mesh *Mesh) {
+	mesh = &Mesh{Indices: append([]uint32(nil), me.Indices...), Positions: append([]Vec3(nil), me.Positions...)}
+	if me.Normals != nil {
+		mesh.Normals = append([]Vec3(nil), me.Normals...)
+	}
+	if me.UVs != nil {
+		mesh.UVs = append([]Vec2(nil), me.UVs...)
+	}
+	if me.Tangents != nil {
+		mesh.Tangents = append([]Vec4(nil), me.Tangents...)
+	}
+	return
+}
+
+//	Sets `Normals` to smooth vertex normals: the weighted sum of the normals of all triangles sharing the vertex's
+//	position (so that normals stay continuous across vertices split only for texture seams), normalized.
+func (me *Mesh) ComputeNormals(weighting MeshNormalWeighting) {
+	weighted := me.weightedNormals(weighting)
+	sums, keys := map[Vec3]*Vec3{}, make([]*Vec3, len(me.Positions))
+	for v := range me.Positions {
+		if keys[v] = sums[me.Positions[v]]; keys[v] == nil {
+			keys[v] = &Vec3{}
+			sums[me.Positions[v]] = keys[v]
+		}
+	}
+	for c := range weighted {
+		keys[me.Indices[c]].Add(&weighted[c])
+	}
+	me.Normals = make([]Vec3, len(me.Positions))
+	for v := range me.Normals {
+		me.Normals[v] = *keys[v]
+		me.Normals[v].NormalizeSafe()
+	}
+}
+
+//	Sets `Tangents` to per-vertex tangent frames computed as by MikkTSpace: at each triangle corner, the UV-space
+//	tangent and bitangent are projected onto the plane of the vertex normal and normalized, then accumulated weighted
+//	by the corner's angle between its edges projected likewise. The sum is orthogonalized against the normal, with
+//	the handedness taken from the accumulated bitangent.
+//	Requires `Normals` and `UVs`. Triangles with degenerate UVs contribute nothing, and vertices left without a
+//	tangent get an arbitrary one perpendicular to their normal.
+func (me *Mesh) ComputeTangents() {
+	tan, bit := make([]Vec3, len(me.Positions)), make([]Vec3, len(me.Positions))
+	var e1, e2, t, b Vec3
+	project := func(vec, n *Vec3) {
+		vec.Subtract(n.Scaled(n.Dot(vec)))
+		vec.NormalizeSafe()
+	}
+	for tri := 0; tri+2 < len(me.Indices); tri += 3 {
+		for k := 0; k < 3; k++ {
+			i0, i1, i2 := me.Indices[tri+k], me.Indices[tri+(k+1)%3], me.Indices[tri+(k+2)%3]
+			e1.SetFromSub(&me.Positions[i1], &me.Positions[i0])
+			e2.SetFromSub(&me.Positions[i2], &me.Positions[i0])
+			u1, v1 := me.UVs[i1].X-me.UVs[i0].X, me.UVs[i1].Y-me.UVs[i0].Y
+			u2, v2 := me.UVs[i2].X-me.UVs[i0].X, me.UVs[i2].Y-me.UVs[i0].Y
+			det := u1*v2 - u2*v1
+			if math.Abs(det) < Epsilon*Epsilon {
+				continue
+			}
+			t.SetFromSub(e1.Scaled(v2), e2.Scaled(v1))
+			b.SetFromSub(e2.Scaled(u1), e1.Scaled(u2))
+			if det < 0 {
+				t.Negate()
+				b.Negate()
+			}
+			//	only the directions within the tangent plane matter
+			n := &me.Normals[i0]
+			project(&t, n)
+			project(&b, n)
+			project(&e1, n)
+			project(&e2, n)
+			w := cornerAngle(&e1, &e2)
+			tan[i0].Add(t.Scaled(w))
+			bit[i0].Add(b.Scaled(w))
+		}
+	}
+	me.Tangents = make([]Vec4, len(me.Positions))
+	for v := range me.Tangents {
+		n := &me.Normals[v]
+		t = tan[v]
+		t.Subtract(n.Scaled(n.Dot(&t)))
+		if t.Magnitude() < Epsilon {
+			//	pick the axis least aligned with the normal
+			t.Set(1, 0, 0)
+			if math.Abs(n.X) > math.Abs(n.Y) {
+				t.Set(0, 1, 0)
+			}
+			t.Subtract(n.Scaled(n.Dot(&t)))
+		}
+		t.Normalize()
+		w := 1.0
+		if n.Cross(&t).Dot(&bit[v]) < 0 {
+			w = -1
+		}
+		me.Tangents[v] = Vec4{t.X, t.Y, t.Z, w}
+	}
+}
+
+//	Reverses the winding order of all triangles, and negates all `Normals`.
+func (me *Mesh) FlipWinding() {
+	for t := 0; t+2 < len(me.Indices); t += 3 {
+		me.Indices[t+1], me.Indices[t+2] = me.Indices[t+2], me.Indices[t+1]
+	}
+	for v := range me.Normals {
+		me.Normals[v].Negate()
+	}
+}
+
+//	Sets `Normals` to smooth vertex normals (as per `ComputeNormals`) except across hard edges: edges whose
+//	adjacent triangles' normals differ by more than `maxAngleDeg` degrees. Vertices on hard edges are duplicated
+//	(with all their attributes) once per smoothing group, and the `Indices` updated accordingly.
+//	Any `Tangents` are copied as-is and should be recomputed afterwards.
+func (me *Mesh) SplitHardEdges(maxAngleDeg float64, weighting MeshNormalWeighting) {
+	weighted, numTris := me.weightedNormals(weighting), len(me.Indices)/3
+	faceNormals := make([]Vec3, numTris)
+	for t := range faceNormals {
+		faceNormals[t] = *me.faceNormal(t)
+		faceNormals[t].NormalizeSafe()
+	}
+	//	union the corners at the same position whose triangles share an edge there at a shallow enough angle
+	parent := make([]int, len(weighted))
+	for c := range parent {
+		parent[c] = c
+	}
+	var find func(int) int
+	find = func(c int) int {
+		if parent[c] != c {
+			parent[c] = find(parent[c])
+		}
+		return parent[c]
+	}
+	type edgeKey struct{ a, b Vec3 }
+	edges, minCos := map[edgeKey][]int{}, math.Cos(DegToRad(maxAngleDeg))
+	for c := range weighted {
+		//	each half-edge from corner c to the next, keyed by its end positions
+		a, b := me.Positions[me.Indices[c]], me.Positions[me.Indices[c-c%3+(c+1)%3]]
+		key := edgeKey{a, b}
+		if b.X < a.X || (b.X == a.X && (b.Y < a.Y || (b.Y == a.Y && b.Z < a.Z))) {
+			key = edgeKey{b, a}
+		}
+		edges[key] = append(edges[key], c)
+	}
+	for _, cs := range edges {
+		for i := 0; i < len(cs); i++ {
+			for j := i + 1; j < len(cs); j++ {
+				ci, cj := cs[i], cs[j]
+				if faceNormals[ci/3].Dot(&faceNormals[cj/3]) < minCos {
+					continue
+				}
+				ni, nj := ci-ci%3+(ci+1)%3, cj-cj%3+(cj+1)%3
+				//	match up the corners at each end of the shared edge
+				for _, pair := range [][2]int{{ci, cj}, {ci, nj}, {ni, cj}, {ni, nj}} {
+					if me.Positions[me.Indices[pair[0]]] == me.Positions[me.Indices[pair[1]]] {
+						parent[find(pair[0])] = find(pair[1])
+					}
+				}
+			}
+		}
+	}
+	groupNormals := map[int]*Vec3{}
+	for c := range weighted {
+		g := find(c)
+		if groupNormals[g] == nil {
+			groupNormals[g] = &Vec3{}
+		}
+		groupNormals[g].Add(&weighted[c])
+	}
+	//	one output vertex per distinct (input vertex, smoothing group)
+	type vertKey struct {
+		v uint32
+		g int
+	}
+	remap, mesh := map[vertKey]uint32{}, Mesh{Indices: make([]uint32, len(weighted))}
+	for c, v := range me.Indices[:len(weighted)] {
+		key := vertKey{v, find(c)}
+		i, ok := remap[key]
+		if !ok {
+			i = uint32(len(mesh.Positions))
+			remap[key] = i
+			mesh.Positions = append(mesh.Positions, me.Positions[v])
+			n := *groupNormals[key.g]
+			n.NormalizeSafe()
+			mesh.Normals = append(mesh.Normals, n)
+			if me.UVs != nil {
+				mesh.UVs = append(mesh.UVs, me.UVs[v])
+			}
+			if me.Tangents != nil {
+				mesh.Tangents = append(mesh.Tangents, me.Tangents[v])
+			}
+		}
+		mesh.Indices[c] = i
+	}
+	*me = mesh
+}
+
+//	Transforms all `Positions` by `mat` (via `Vec3.TransformCoord`), and all `Normals` (via `Vec3.TransformNormal`)
+//	and `Tangents` accordingly. If `mat` mirrors (has a negative determinant), the winding order of all triangles
+//	and the handedness of all `Tangents` are reversed so that triangles keep facing outwards.
+func (me *Mesh) Transform(mat *Mat4) {
+	var m3, inv Mat3
+	var normMat Mat4
+	m3.SetFromMat4(mat)
+	if inv.SetFromInverseOf(&m3) {
+		//	`TransformNormal` multiplies by the transpose, so this yields the inverse transpose of `mat`
+		normMat[0], normMat[1], normMat[2] = inv[0], inv[1], inv[2]
+		normMat[4], normMat[5], normMat[6] = inv[3], inv[4], inv[5]
+		normMat[8], normMat[9], normMat[10] = inv[6], inv[7], inv[8]
+	}
+	for v := range me.Positions {
+		me.Positions[v].TransformCoord(mat)
+	}
+	for v := range me.Normals {
+		me.Normals[v].TransformNormal(&normMat, false)
+		me.Normals[v].NormalizeSafe()
+	}
+	mirror := m3.Determinant() < 0
+	for v := range me.Tangents {
+		t := &me.Tangents[v]
+		dir := Vec3{t.X, t.Y, t.Z}
+		dir.MultMat3(&m3)
+		dir.NormalizeSafe()
+		if t.X, t.Y, t.Z = dir.X, dir.Y, dir.Z; mirror {
+			t.W = -t.W
+		}
+	}
+	if mirror {
+		for t := 0; t+2 < len(me.Indices); t += 3 {
+			me.Indices[t+1], me.Indices[t+2] = me.Indices[t+2], me.Indices[t+1]
+		}
+	}
+}
+
+//	Returns the number of triangles in `me`.
+func (me *Mesh) TriangleCount() int {
+	return len(me.Indices) / 3
+}
+
+//	Returns the non-normalized normal of triangle `t`, whose magnitude is twice its area.
+func (me *Mesh) faceNormal(t int) (n *Vec3) {
+	var e1, e2 Vec3
+	a := &me.Positions[me.Indices[3*t]]
+	e1.SetFromSub(&me.Positions[me.Indices[3*t+1]], a)
+	e2.SetFromSub(&me.Positions[me.Indices[3*t+2]], a)
+	return e1.Cross(&e2)
+}
+
+//	Returns, for each corner in `Indices`, the normal of its triangle weighted according to `weighting`.
+func (me *Mesh) weightedNormals(weighting MeshNormalWeighting) (weighted []Vec3) {
+	weighted = make([]Vec3, len(me.Indices)-len(me.Indices)%3)
+	var e1, e2 Vec3
+	for t := 0; t < len(weighted)/3; t++ {
+		n := me.faceNormal(t)
+		unit := *n
+		unit.NormalizeSafe()
+		for k := 0; k < 3; k++ {
+			a := &me.Positions[me.Indices[3*t+k]]
+			e1.SetFromSub(&me.Positions[me.Indices[3*t+(k+1)%3]], a)
+			e2.SetFromSub(&me.Positions[me.Indices[3*t+(k+2)%3]], a)
+			switch weighting {
+			case MeshWeightArea:
+				weighted[3*t+k] = *n
+			case MeshWeightAngle:
+				weighted[3*t+k] = *unit.Scaled(cornerAngle(&e1, &e2))
+			default:
+				weighted[3*t+k] = *n.Scaled(cornerAngle(&e1, &e2))
+			}
+		}
+	}
+	return
+}
+
+//	Returns the angle in radians between the edges `e1` and `e2` leaving a corner.
+func cornerAngle(e1, e2 *Vec3) float64 {
+	return math.Atan2(e1.Cross(e2).Magnitude(), e1.Dot(e2))
+}
@@ -0,0 +1,223 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents an oriented bounding box.
+type OBB struct {
+	//	World-space center.
+	Center Vec3
+
+	//	Half-size along each of the 3 local axes.
+	HalfExtents Vec3
+
+	//	Orientation: column `i` holds the unit-length world-space direction of local axis `i`.
+	Axes Mat3
+}
+
+//	Returns a new `*OBB` at `center` with the specified `halfExtents`, oriented according to the unit quaternion `rot`.
+func NewOBB(center, halfExtents *Vec3, rot *Quat) (me *OBB) {
+	me = &OBB{Center: *center, HalfExtents: *halfExtents}
+	me.Axes.SetFromQuat(rot)
+	return
+}
+
+//	Returns a new `*OBB` equivalent to `aabb`.
+func NewOBBFromAABB(aabb *AABB) (me *OBB) {
+	me = &OBB{Center: *aabb.Center(), HalfExtents: *aabb.HalfExtents(), Axes: Mat3Identity}
+	return
+}
+
+//	Returns a new `*OBB` enclosing all specified `points`, oriented along their principal axes as
+//	obtained from the eigenvectors of their covariance matrix. Returns `nil` if no `points` are specified.
+func NewOBBFromPoints(points []Vec3) (me *OBB) {
+	if len(points) == 0 {
+		return
+	}
+	var mean, d, e Vec3
+	var cov Mat3
+	for i := range points {
+		mean.Add(&points[i])
+	}
+	mean.Divide(float64(len(points)))
+	for i := range points {
+		d.SetFromSub(&points[i], &mean)
+		cov[0], cov[4], cov[8] = cov[0]+d.X*d.X, cov[4]+d.Y*d.Y, cov[8]+d.Z*d.Z
+		cov[1], cov[2], cov[5] = cov[1]+d.X*d.Y, cov[2]+d.X*d.Z, cov[5]+d.Y*d.Z
+	}
+	cov[3], cov[6], cov[7] = cov[1], cov[2], cov[5]
+	_, vecs := cov.SymmetricEigen()
+	me = &OBB{Axes: *vecs}
+	//	ensure a right-handed basis
+	ax, ay := me.Axis(0), me.Axis(1)
+	e.SetFromCrossOf(ax, ay)
+	me.setAxis(2, &e)
+	min, max := Vec3{}, Vec3{}
+	min.SetToMax()
+	max.SetToMin()
+	for i := range points {
+		d.SetFromSub(&points[i], &mean)
+		e.Set(d.Dot(ax), d.Dot(ay), d.Dot(me.Axis(2)))
+		min.Set(math.Min(min.X, e.X), math.Min(min.Y, e.Y), math.Min(min.Z, e.Z))
+		max.Set(math.Max(max.X, e.X), math.Max(max.Y, e.Y), math.Max(max.Z, e.Z))
+	}
+	me.HalfExtents.SetFromScaledSub(&max, &min, 0.5)
+	e.SetFromAdd(&min, &max)
+	e.Scale(0.5)
+	me.Center.MultMat3Vec3(&me.Axes, &e)
+	me.Center.Add(&mean)
+	return
+}
+
+//	Returns the world-space direction of local axis `i` (0, 1 or 2).
+func (me *OBB) Axis(i int) *Vec3 {
+	return &Vec3{me.Axes[i*3], me.Axes[i*3+1], me.Axes[i*3+2]}
+}
+
+//	Returns a new `*AABB` tightly enclosing `me`.
+func (me *OBB) Bounds() *AABB {
+	h := &Vec3{
+		math.Abs(me.Axes[0])*me.HalfExtents.X + math.Abs(me.Axes[3])*me.HalfExtents.Y + math.Abs(me.Axes[6])*me.HalfExtents.Z,
+		math.Abs(me.Axes[1])*me.HalfExtents.X + math.Abs(me.Axes[4])*me.HalfExtents.Y + math.Abs(me.Axes[7])*me.HalfExtents.Z,
+		math.Abs(me.Axes[2])*me.HalfExtents.X + math.Abs(me.Axes[5])*me.HalfExtents.Y + math.Abs(me.Axes[8])*me.HalfExtents.Z,
+	}
+	return NewAABBCenter(&me.Center, h)
+}
+
+//	Returns the point in (or on) `me` that is closest to `point`.
+func (me *OBB) ClosestPoint(point *Vec3) (cp *Vec3) {
+	var d Vec3
+	d.SetFromSub(point, &me.Center)
+	cp = &Vec3{me.Center.X, me.Center.Y, me.Center.Z}
+	for i, h := range [3]float64{me.HalfExtents.X, me.HalfExtents.Y, me.HalfExtents.Z} {
+		ax := me.Axis(i)
+		cp.SetFromAddScaled(cp, ax, Clamp(d.Dot(ax), -h, h))
+	}
+	return
+}
+
+//	Returns whether `point` lies within (or on the boundary of) `me`.
+func (me *OBB) ContainsPoint(point *Vec3) bool {
+	var d, l Vec3
+	d.SetFromSub(point, &me.Center)
+	l.MultMat3Vec3(me.Axes.Transposed(), &d)
+	return math.Abs(l.X) <= me.HalfExtents.X && math.Abs(l.Y) <= me.HalfExtents.Y && math.Abs(l.Z) <= me.HalfExtents.Z
+}
+
+//	Returns the 8 world-space corners of `me`.
+func (me *OBB) Corners() (corners [8]Vec3) {
+	var ax, ay, az Vec3
+	ax.SetFromScaled(me.Axis(0), me.HalfExtents.X)
+	ay.SetFromScaled(me.Axis(1), me.HalfExtents.Y)
+	az.SetFromScaled(me.Axis(2), me.HalfExtents.Z)
+	for i := range corners {
+		c := &corners[i]
+		*c = me.Center
+		c.SetFromAddScaled(c, &ax, float64((i&1)*2-1))
+		c.SetFromAddScaled(c, &ay, float64((i>>1&1)*2-1))
+		c.SetFromAddScaled(c, &az, float64((i>>2&1)*2-1))
+	}
+	return
+}
+
+//	Returns whether `me` and `aabb` overlap (or touch).
+func (me *OBB) IntersectsAABB(aabb *AABB) bool {
+	return me.IntersectsOBB(NewOBBFromAABB(aabb))
+}
+
+//	Returns whether `me` and `obb` overlap (or touch), by testing all 15 potentially separating axes.
+func (me *OBB) IntersectsOBB(obb *OBB) bool {
+	var r, absR [3][3]float64
+	var d Vec3
+	a, b := [3]float64{me.HalfExtents.X, me.HalfExtents.Y, me.HalfExtents.Z}, [3]float64{obb.HalfExtents.X, obb.HalfExtents.Y, obb.HalfExtents.Z}
+	axA, axB := [3]*Vec3{me.Axis(0), me.Axis(1), me.Axis(2)}, [3]*Vec3{obb.Axis(0), obb.Axis(1), obb.Axis(2)}
+	//	`r` expresses `obb` in the frame of `me`; `absR` adds an epsilon to counteract arithmetic errors when edges are near-parallel
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = axA[i].Dot(axB[j])
+			absR[i][j] = math.Abs(r[i][j]) + EpsilonEqFloatFactor
+		}
+	}
+	d.SetFromSub(&obb.Center, &me.Center)
+	t := [3]float64{d.Dot(axA[0]), d.Dot(axA[1]), d.Dot(axA[2])}
+	for i := 0; i < 3; i++ {
+		if math.Abs(t[i]) > a[i]+b[0]*absR[i][0]+b[1]*absR[i][1]+b[2]*absR[i][2] {
+			return false
+		}
+	}
+	for j := 0; j < 3; j++ {
+		if math.Abs(t[0]*r[0][j]+t[1]*r[1][j]+t[2]*r[2][j]) > b[j]+a[0]*absR[0][j]+a[1]*absR[1][j]+a[2]*absR[2][j] {
+			return false
+		}
+	}
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+			ra := a[i1]*absR[i2][j] + a[i2]*absR[i1][j]
+			rb := b[j1]*absR[i][j2] + b[j2]*absR[i][j1]
+			if math.Abs(t[i2]*r[i1][j]-t[i1]*r[i2][j]) > ra+rb {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (me *OBB) setAxis(i int, axis *Vec3) {
+	me.Axes[i*3], me.Axes[i*3+1], me.Axes[i*3+2] = axis.X, axis.Y, axis.Z
+}
+
+//	Returns the squared distance from `point` to `me`, or 0 if `point` lies within `me`.
+func (me *OBB) SqDistance(point *Vec3) float64 {
+	return me.ClosestPoint(point).Sub(point).Length()
+}
+
+//	Returns the corner of `me` that is farthest along `dir`.
+func (me *OBB) Support(dir Vec3) (p Vec3) {
+	p = me.Center
+	for i, h := range [3]float64{me.HalfExtents.X, me.HalfExtents.Y, me.HalfExtents.Z} {
+		ax := me.Axis(i)
+		if ax.Dot(&dir) < 0 {
+			h = -h
+		}
+		p.SetFromAddScaled(&p, ax, h)
+	}
+	return
+}
+
+//	Sets `me` to the `OBB` resulting from transforming `me` by the affine `mat`.
+//
+//	Any scaling in `mat` is folded into `HalfExtents`, which become 0 along any axes that `mat` collapses.
+//	Since an `OBB` cannot represent shear, the transformed axes are re-orthonormalized, which makes the result
+//	approximate for shearing transforms.
+func (me *OBB) Transform(mat *Mat4) {
+	var m Mat3
+	var ax [3]Vec3
+	m.SetFromMat4(mat)
+	me.Center.TransformCoord(mat)
+	h := [3]*float64{&me.HalfExtents.X, &me.HalfExtents.Y, &me.HalfExtents.Z}
+	for i := range ax {
+		ax[i].MultMat3Vec3(&m, me.Axis(i))
+		if l := ax[i].Magnitude(); l == 0 {
+			//	`mat` collapses this axis: keep its direction, flattening `me` along it
+			ax[i], *h[i] = *me.Axis(i), 0
+		} else {
+			*h[i] *= l
+			ax[i].Divide(l)
+		}
+	}
+	//	Gram-Schmidt
+	ax[1].SetFromSubScaled(&ax[1], &ax[0], ax[1].Dot(&ax[0]))
+	ax[1].Normalize()
+	ax[2].SetFromCrossOf(&ax[0], &ax[1])
+	for i := range ax {
+		me.setAxis(i, &ax[i])
+	}
+}
+
+//	Returns the volume of `me`.
+func (me *OBB) Volume() float64 {
+	return 8 * me.HalfExtents.X * me.HalfExtents.Y * me.HalfExtents.Z
+}
@@ -0,0 +1,256 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Computes the derivative `dydt` of the state `y` at time `t`. `dydt` has the same length as `y`.
+type OdeFunc func(t float64, y, dydt []float64)
+
+//	Computes the acceleration `acc` at time `t` for the positions `pos` and velocities `vel`. All slices have the same length.
+type OdeAccelFunc func(t float64, pos, vel, acc []float64)
+
+//	Computes the acceleration `acc` at time `t` for the position `pos` and velocity `vel`.
+type Vec3AccelFunc func(t float64, pos, vel, acc *Vec3)
+
+//	Dormand-Prince 5(4) tableau.
+var (
+	odeDopriC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	odeDopriA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	//	Difference between the 5th-order and the embedded 4th-order weights.
+	odeDopriE = [7]float64{71.0 / 57600, 0, -71.0 / 16695, 71.0 / 1920, -17253.0 / 339200, 22.0 / 525, -1.0 / 40}
+)
+
+//	Integrates state slices with various fixed-step and adaptive-step schemes.
+//
+//	The zero value is ready to use; its scratch buffers grow on demand and are reused across steps,
+//	so an `Ode` must not be used by multiple goroutines concurrently.
+type Ode struct {
+	//	Absolute and relative error tolerances for `RK45` and `Integrate`. If both are 0, 1E-06 is used for each.
+	AbsTol, RelTol float64
+
+	//	Lower and upper limits for adaptive step sizes. 0 means unlimited.
+	MinStep, MaxStep float64
+
+	k   [7][]float64
+	tmp []float64
+}
+
+func (me *Ode) grow(n int) {
+	if len(me.tmp) != n {
+		for i := range me.k {
+			me.k[i] = make([]float64, n)
+		}
+		me.tmp = make([]float64, n)
+	}
+}
+
+//	Advances `y` from `t` by `h` in-place using the explicit (forward) Euler method.
+func (me *Ode) Euler(f OdeFunc, t, h float64, y []float64) {
+	me.grow(len(y))
+	f(t, y, me.k[0])
+	for i, d := range me.k[0] {
+		y[i] += h * d
+	}
+}
+
+//	Advances `pos` and `vel` from `t` by `h` in-place using the semi-implicit (symplectic) Euler method:
+//	the velocity is updated first, then the position is advanced with the new velocity.
+func (me *Ode) SemiImplicitEuler(accel OdeAccelFunc, t, h float64, pos, vel []float64) {
+	me.grow(len(pos))
+	accel(t, pos, vel, me.k[0])
+	for i, a := range me.k[0] {
+		vel[i] += h * a
+		pos[i] += h * vel[i]
+	}
+}
+
+//	Advances `pos` and `vel` from `t` by `h` in-place using the velocity Verlet method.
+//
+//	`acc` must hold the acceleration at `t` on entry, and holds the acceleration at `t + h` on return,
+//	so it can be passed unchanged into the next step. For velocity-dependent accelerations, a first-order
+//	velocity estimate is used when evaluating the acceleration at `t + h`.
+func (me *Ode) Verlet(accel OdeAccelFunc, t, h float64, pos, vel, acc []float64) {
+	me.grow(len(pos))
+	for i := range pos {
+		pos[i] += h*vel[i] + 0.5*h*h*acc[i]
+		me.tmp[i] = vel[i] + h*acc[i]
+	}
+	accel(t+h, pos, me.tmp, me.k[0])
+	for i, a := range me.k[0] {
+		vel[i] += 0.5 * h * (acc[i] + a)
+		acc[i] = a
+	}
+}
+
+//	Advances `y` from `t` by `h` in-place using the classical 4th-order Runge-Kutta method.
+func (me *Ode) RK4(f OdeFunc, t, h float64, y []float64) {
+	me.grow(len(y))
+	k1, k2, k3, k4, tmp := me.k[0], me.k[1], me.k[2], me.k[3], me.tmp
+	f(t, y, k1)
+	for i := range y {
+		tmp[i] = y[i] + 0.5*h*k1[i]
+	}
+	f(t+0.5*h, tmp, k2)
+	for i := range y {
+		tmp[i] = y[i] + 0.5*h*k2[i]
+	}
+	f(t+0.5*h, tmp, k3)
+	for i := range y {
+		tmp[i] = y[i] + h*k3[i]
+	}
+	f(t+h, tmp, k4)
+	for i := range y {
+		y[i] += (h / 6) * (k1[i] + 2*(k2[i]+k3[i]) + k4[i])
+	}
+}
+
+//	Attempts to advance `y` from `t` by at most `h` in-place using one adaptive Dormand-Prince 5(4) step.
+//
+//	Returns the step size `hDone` actually taken (after any rejections) and the suggested size `hNext`
+//	for the following step. `MinStep` only limits how far rejected steps shrink: an `h` already below it, such as
+//	the final step of `Integrate`, is attempted as is. If `MinStep` prevents the error tolerances from being met,
+//	the step is taken anyway and `ok` is `false`. Steps for which `f` yields NaN or infinite values are rejected;
+//	if no step size down to `MinStep` (or to 0) avoids them, `y` is left unchanged and `hDone`, `hNext` are 0.
+func (me *Ode) RK45(f OdeFunc, t, h float64, y []float64) (hDone, hNext float64, ok bool) {
+	me.grow(len(y))
+	atol, rtol := me.AbsTol, me.RelTol
+	if atol == 0 && rtol == 0 {
+		atol, rtol = 1E-06, 1E-06
+	}
+	if me.MaxStep > 0 && math.Abs(h) > me.MaxStep {
+		h = math.Copysign(me.MaxStep, h)
+	}
+	//	rejections shrink `h` no further than `MinStep`, but a shorter `h` is never lengthened
+	minStep := math.Min(me.MinStep, math.Abs(h))
+	f(t, y, me.k[0])
+	for {
+		for s := 1; s < 7; s++ {
+			for i := range y {
+				sum := 0.0
+				for j := 0; j < s; j++ {
+					sum += odeDopriA[s][j] * me.k[j][i]
+				}
+				me.tmp[i] = y[i] + h*sum
+			}
+			f(t+odeDopriC[s]*h, me.tmp, me.k[s])
+		}
+		errNorm := 0.0
+		for i := range y {
+			sum := 0.0
+			for s, e := range odeDopriE {
+				sum += e * me.k[s][i]
+			}
+			sc := atol + rtol*math.Max(math.Abs(y[i]), math.Abs(me.tmp[i]))
+			e := h * sum / sc
+			errNorm += e * e
+		}
+		if len(y) > 0 {
+			errNorm = math.Sqrt(errNorm / float64(len(y)))
+		}
+		factor, finite, atMin := 5.0, !(math.IsNaN(errNorm) || math.IsInf(errNorm, 0)), minStep > 0 && math.Abs(h) <= minStep
+		if !finite {
+			//	`f` blew up somewhere within the step: retry with the smallest allowed shrink
+			factor = 0.2
+		} else if errNorm > 0 {
+			factor = Clamp(0.9*math.Pow(errNorm, -0.2), 0.2, 5)
+		}
+		if ok = errNorm <= 1; finite && (ok || atMin) {
+			//	the 7th stage is evaluated at the 5th-order solution, which `tmp` still holds
+			copy(y, me.tmp)
+			return h, me.clampStep(h * factor), ok
+		}
+		if h *= factor; h == 0 || atMin {
+			return 0, 0, false
+		} else if math.Abs(h) < minStep {
+			h = math.Copysign(minStep, h)
+		}
+	}
+}
+
+func (me *Ode) clampStep(h float64) float64 {
+	if me.MaxStep > 0 && math.Abs(h) > me.MaxStep {
+		h = math.Copysign(me.MaxStep, h)
+	}
+	if me.MinStep > 0 && math.Abs(h) < me.MinStep {
+		h = math.Copysign(me.MinStep, h)
+	}
+	return h
+}
+
+//	Advances `y` from `t0` to `t1` in-place with as many adaptive `RK45` steps as needed, starting with step size `h`
+//	(or, if 0, with a single step across the whole interval, shrunk as needed). The final step is shortened to end
+//	exactly at `t1`, even below `MinStep`.
+//
+//	Returns the suggested step size for continuing the integration, and whether all steps met the error tolerances.
+//	If `RK45` cannot make progress, integration stops short of `t1` with `ok` being `false`.
+func (me *Ode) Integrate(f OdeFunc, t0, t1, h float64, y []float64) (hNext float64, ok bool) {
+	var stepOk bool
+	if h == 0 {
+		h = t1 - t0
+	}
+	ok, hNext = true, math.Copysign(math.Abs(h), t1-t0)
+	for t := t0; (t1-t)*hNext > 0; {
+		if h = hNext; (t+h-t1)*h > 0 {
+			h = t1 - t
+		}
+		if h, hNext, stepOk = me.RK45(f, t, h, y); !stepOk {
+			ok = false
+		}
+		t += h
+	}
+	return
+}
+
+//	Advances `pos` and `vel` from `t` by `h` in-place using the semi-implicit (symplectic) Euler method.
+func Vec3SemiImplicitEuler(accel Vec3AccelFunc, t, h float64, pos, vel *Vec3) {
+	var acc Vec3
+	accel(t, pos, vel, &acc)
+	vel.SetFromAddScaled(vel, &acc, h)
+	pos.SetFromAddScaled(pos, vel, h)
+}
+
+//	Advances `pos` and `vel` from `t` by `h` in-place using the velocity Verlet method.
+//
+//	`acc` must hold the acceleration at `t` on entry, and holds the acceleration at `t + h` on return.
+func Vec3VelocityVerlet(accel Vec3AccelFunc, t, h float64, pos, vel, acc *Vec3) {
+	var accNext, velEst Vec3
+	pos.SetFromAddScaled(pos, vel, h)
+	pos.SetFromAddScaled(pos, acc, 0.5*h*h)
+	velEst.SetFromAddScaled(vel, acc, h)
+	accel(t+h, pos, &velEst, &accNext)
+	acc.Add(&accNext)
+	vel.SetFromAddScaled(vel, acc, 0.5*h)
+	*acc = accNext
+}
+
+//	Advances `pos` and `vel` from `t` by `h` in-place using the classical 4th-order Runge-Kutta method.
+func Vec3RK4(accel Vec3AccelFunc, t, h float64, pos, vel *Vec3) {
+	var a1, a2, a3, a4, p, v2, v3, v4 Vec3
+	hh := 0.5 * h
+	accel(t, pos, vel, &a1)
+	p.SetFromAddScaled(pos, vel, hh)
+	v2.SetFromAddScaled(vel, &a1, hh)
+	accel(t+hh, &p, &v2, &a2)
+	p.SetFromAddScaled(pos, &v2, hh)
+	v3.SetFromAddScaled(vel, &a2, hh)
+	accel(t+hh, &p, &v3, &a3)
+	p.SetFromAddScaled(pos, &v3, h)
+	v4.SetFromAddScaled(vel, &a3, h)
+	accel(t+h, &p, &v4, &a4)
+	h /= 6
+	pos.X += h * (vel.X + 2*(v2.X+v3.X) + v4.X)
+	pos.Y += h * (vel.Y + 2*(v2.Y+v3.Y) + v4.Y)
+	pos.Z += h * (vel.Z + 2*(v2.Z+v3.Z) + v4.Z)
+	vel.X += h * (a1.X + 2*(a2.X+a3.X) + a4.X)
+	vel.Y += h * (a1.Y + 2*(a2.Y+a3.Y) + a4.Y)
+	vel.Z += h * (a1.Z + 2*(a2.Z+a3.Z) + a4.Z)
+}
@@ -0,0 +1,46 @@
+package unum
+
+//	Represents a plane as the set of all points `p` for which `Normal.Dot(p) == Dist`.
+type Plane struct {
+	//	The unit-length plane normal.
+	Normal Vec3
+
+	//	The signed distance of the plane from the origin along `Normal`.
+	Dist float64
+}
+
+//	Returns a new `*Plane` through the point `point` with the specified `normal`, which is normalized.
+func NewPlane(normal, point *Vec3) (me *Plane) {
+	me = &Plane{Normal: *normal.Normalized()}
+	me.Dist = me.Normal.Dot(point)
+	return
+}
+
+//	Returns a new `*Plane` through the points `a`, `b` and `c`, facing the side from which they appear counter-clockwise.
+//	The `Normal` is the zero `Vec3` if the points are collinear.
+func NewPlaneFromPoints(a, b, c *Vec3) (me *Plane) {
+	var ab, ac Vec3
+	ab.SetFromSub(b, a)
+	ac.SetFromSub(c, a)
+	me = new(Plane)
+	me.Normal.SetFromCrossOf(&ab, &ac)
+	me.Normal.NormalizeSafe()
+	me.Dist = me.Normal.Dot(a)
+	return
+}
+
+//	Returns the point on `me` that is closest to `point`.
+func (me *Plane) ClosestPoint(point *Vec3) *Vec3 {
+	return me.Normal.ScaledAdded(-me.SignedDistance(point), point)
+}
+
+//	Reverses the facing of `me`.
+func (me *Plane) Flip() {
+	me.Normal.Negate()
+	me.Dist = -me.Dist
+}
+
+//	Returns the signed distance of `point` from `me`: positive in front, negative behind.
+func (me *Plane) SignedDistance(point *Vec3) float64 {
+	return me.Normal.Dot(point) - me.Dist
+}
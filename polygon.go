@@ -0,0 +1,185 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents a 2D polygon as its sequence of vertices; the closing edge from the last vertex back to the first is implied.
+type Polygon []Vec2
+
+//	Returns the (unsigned) area enclosed by `me`.
+func (me Polygon) Area() float64 {
+	return math.Abs(me.SignedArea())
+}
+
+//	Returns the minimum and maximum corners of the axis-aligned rectangle enclosing `me`.
+func (me Polygon) Bounds() (min, max *Vec2) {
+	min, max = &Vec2{math.MaxFloat64, math.MaxFloat64}, &Vec2{-math.MaxFloat64, -math.MaxFloat64}
+	for i := range me {
+		min.X, min.Y = math.Min(min.X, me[i].X), math.Min(min.Y, me[i].Y)
+		max.X, max.Y = math.Max(max.X, me[i].X), math.Max(max.Y, me[i].Y)
+	}
+	return
+}
+
+//	Returns the center of mass of the area enclosed by `me`, or the vertex average if that area is 0.
+func (me Polygon) Centroid() (c *Vec2) {
+	c = &Vec2{}
+	a := 0.0
+	for i := range me {
+		p, q := &me[i], &me[(i+1)%len(me)]
+		f := p.Cross(q)
+		a += f
+		c.X, c.Y = c.X+(p.X+q.X)*f, c.Y+(p.Y+q.Y)*f
+	}
+	if a != 0 {
+		c.Scale(1 / (3 * a))
+	} else if len(me) > 0 {
+		c.Clear()
+		for i := range me {
+			c.Add(&me[i])
+		}
+		c.Divide(float64(len(me)))
+	}
+	return
+}
+
+//	Returns whether `point` lies inside `me`, according to the non-zero winding rule.
+func (me Polygon) ContainsPoint(point *Vec2) bool {
+	return me.WindingNumber(point) != 0
+}
+
+//	Returns whether the vertices of `me` are in counter-clockwise order.
+func (me Polygon) IsCCW() bool {
+	return me.SignedArea() > 0
+}
+
+//	Returns whether `me` is convex (and not self-intersecting). Collinear consecutive edges are permitted.
+func (me Polygon) IsConvex() bool {
+	if len(me) < 3 {
+		return false
+	}
+	sign, xFlips, yFlips := 0.0, 0, 0
+	var e0, e1 Vec2
+	e0 = *me[0].Sub(&me[len(me)-1])
+	for i := range me {
+		e1 = *me[(i+1)%len(me)].Sub(&me[i])
+		if c := e0.Cross(&e1); c != 0 {
+			if sign != 0 && (c > 0) != (sign > 0) {
+				return false
+			}
+			sign = c
+		}
+		//	a simple convex polygon changes its horizontal and vertical directions exactly twice each
+		if e0.X*e1.X < 0 {
+			xFlips++
+		}
+		if e0.Y*e1.Y < 0 {
+			yFlips++
+		}
+		if e1.X != 0 || e1.Y != 0 {
+			e0 = e1
+		}
+	}
+	return sign != 0 && xFlips <= 2 && yFlips <= 2
+}
+
+//	Returns whether no two non-adjacent edges of `me` intersect, by testing all edge pairs.
+func (me Polygon) IsSimple() bool {
+	n := len(me)
+	if n < 3 {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			if SegmentsIntersect(&me[i], &me[(i+1)%n], &me[j], &me[(j+1)%n]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//	Returns the total length of all edges of `me`.
+func (me Polygon) Perimeter() (l float64) {
+	for i := range me {
+		l += me[i].Distance(&me[(i+1)%len(me)])
+	}
+	return
+}
+
+//	Reverses the vertex order (and so the winding) of `me` in-place.
+func (me Polygon) Reverse() {
+	for i, j := 0, len(me)-1; i < j; i, j = i+1, j-1 {
+		me[i], me[j] = me[j], me[i]
+	}
+}
+
+//	Returns the signed area enclosed by `me`: positive if its vertices are in counter-clockwise order, negative if clockwise.
+func (me Polygon) SignedArea() (a float64) {
+	for i := range me {
+		a += me[i].Cross(&me[(i+1)%len(me)])
+	}
+	return a * 0.5
+}
+
+//	Returns the winding number of `me` around `point`: the number of counter-clockwise turns (negative for clockwise)
+//	that `me` makes around `point`, or 0 if `point` is outside.
+func (me Polygon) WindingNumber(point *Vec2) (wn int) {
+	for i := range me {
+		a, b := &me[i], &me[(i+1)%len(me)]
+		if a.Y <= point.Y {
+			if b.Y > point.Y && Orient2D(a, b, point) > 0 {
+				wn++
+			}
+		} else if b.Y <= point.Y && Orient2D(a, b, point) < 0 {
+			wn--
+		}
+	}
+	return
+}
+
+//	Intersects the segment from `a0` to `a1` with the segment from `b0` to `b1`.
+//
+//	If they intersect, returns the intersection `point` plus its parameters `t` along `a` and `u` along `b` (both in [0, 1]).
+//	For collinear overlapping segments, the overlap's start closest to `a0` is returned.
+func SegmentIntersect(a0, a1, b0, b1 *Vec2) (point *Vec2, t, u float64, ok bool) {
+	r, s, d := a1.Sub(a0), b1.Sub(b0), b0.Sub(a0)
+	denom, rr := r.Cross(s), r.Dot(r)
+	if math.Abs(denom) <= Epsilon*math.Sqrt(rr*s.Dot(s)) {
+		if math.Abs(d.Cross(r)) > Epsilon*math.Sqrt(rr*d.Dot(d)) || rr == 0 {
+			//	parallel but not collinear, or degenerate `a`
+			return
+		}
+		t0, t1 := d.Dot(r)/rr, (d.Dot(r)+s.Dot(r))/rr
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if t0, t1 = math.Max(t0, 0), math.Min(t1, 1); t0 > t1 {
+			return
+		}
+		t, ok = t0, true
+		point = r.Scaled(t)
+		point.Add(a0)
+		if ss := s.Dot(s); ss > 0 {
+			u = point.Sub(b0).Dot(s) / ss
+		}
+		return
+	}
+	t, u = d.Cross(s)/denom, d.Cross(r)/denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return
+	}
+	ok, point = true, r.Scaled(t)
+	point.Add(a0)
+	return
+}
+
+//	Returns whether the segment from `a0` to `a1` intersects (or touches) the segment from `b0` to `b1`.
+func SegmentsIntersect(a0, a1, b0, b1 *Vec2) bool {
+	_, _, _, ok := SegmentIntersect(a0, a1, b0, b1)
+	return ok
+}
@@ -0,0 +1,242 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Error bound coefficients for the fast (plain floating-point) evaluations, following Shewchuk's
+//	"Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric Predicates".
+var (
+	predEps           = math.Ldexp(1, -53)
+	predOrient2DBound = (3 + 16*predEps) * predEps
+	predOrient3DBound = (7 + 56*predEps) * predEps
+	predInCircleBound = (10 + 96*predEps) * predEps
+	predInSphereBound = (16 + 224*predEps) * predEps
+)
+
+//	Returns twice the signed area of the triangle `a`, `b`, `c`: positive if counter-clockwise, negative if clockwise,
+//	0 if collinear. The sign is always exact: if the fast floating-point estimate is too close to 0 to be certain,
+//	the determinant is re-evaluated with exact (arbitrary-precision) floating-point expansions.
+func Orient2D(a, b, c *Vec2) float64 {
+	detLeft, detRight := float64((a.X-c.X)*(b.Y-c.Y)), float64((a.Y-c.Y)*(b.X-c.X))
+	det, detSum := detLeft-detRight, 0.0
+	if detLeft > 0 {
+		if detRight <= 0 {
+			return det
+		}
+		detSum = detLeft + detRight
+	} else if detLeft < 0 {
+		if detRight >= 0 {
+			return det
+		}
+		detSum = -detLeft - detRight
+	} else {
+		return det
+	}
+	if bound := predOrient2DBound * detSum; det >= bound || -det >= bound {
+		return det
+	}
+	acx, acy, bcx, bcy := expDiff(a.X, c.X), expDiff(a.Y, c.Y), expDiff(b.X, c.X), expDiff(b.Y, c.Y)
+	return acx.mul(bcy).sub(acy.mul(bcx)).estimate()
+}
+
+//	Returns 6 times the signed volume of the tetrahedron `a`, `b`, `c`, `d`: positive if `d` lies behind the plane
+//	through `a`, `b` and `c` (so that these appear clockwise when seen from `d`), negative if in front, 0 if coplanar.
+//	The sign is always exact, as for `Orient2D`.
+func Orient3D(a, b, c, d *Vec3) float64 {
+	adx, bdx, cdx := a.X-d.X, b.X-d.X, c.X-d.X
+	ady, bdy, cdy := a.Y-d.Y, b.Y-d.Y, c.Y-d.Y
+	adz, bdz, cdz := a.Z-d.Z, b.Z-d.Z, c.Z-d.Z
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	det := float64(adz*(bdxcdy-cdxbdy)) + float64(bdz*(cdxady-adxcdy)) + float64(cdz*(adxbdy-bdxady))
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) + (math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+	if bound := predOrient3DBound * permanent; det > bound || -det > bound {
+		return det
+	}
+	eadx, ebdx, ecdx := expDiff(a.X, d.X), expDiff(b.X, d.X), expDiff(c.X, d.X)
+	eady, ebdy, ecdy := expDiff(a.Y, d.Y), expDiff(b.Y, d.Y), expDiff(c.Y, d.Y)
+	eadz, ebdz, ecdz := expDiff(a.Z, d.Z), expDiff(b.Z, d.Z), expDiff(c.Z, d.Z)
+	bc := ebdx.mul(ecdy).sub(ecdx.mul(ebdy))
+	ca := ecdx.mul(eady).sub(eadx.mul(ecdy))
+	ab := eadx.mul(ebdy).sub(ebdx.mul(eady))
+	return eadz.mul(bc).add(ebdz.mul(ca)).add(ecdz.mul(ab)).estimate()
+}
+
+//	Returns a positive value if `d` lies inside the circle through the counter-clockwise points `a`, `b` and `c`,
+//	a negative value if outside, or 0 if on it (the signs are reversed for clockwise `a`, `b`, `c`).
+//	The sign is always exact, as for `Orient2D`.
+func InCircle(a, b, c, d *Vec2) float64 {
+	adx, bdx, cdx := a.X-d.X, b.X-d.X, c.X-d.X
+	ady, bdy, cdy := a.Y-d.Y, b.Y-d.Y, c.Y-d.Y
+	bdxcdy, cdxbdy := float64(bdx*cdy), float64(cdx*bdy)
+	cdxady, adxcdy := float64(cdx*ady), float64(adx*cdy)
+	adxbdy, bdxady := float64(adx*bdy), float64(bdx*ady)
+	alift, blift, clift := float64(adx*adx)+float64(ady*ady), float64(bdx*bdx)+float64(bdy*bdy), float64(cdx*cdx)+float64(cdy*cdy)
+	det := float64(alift*(bdxcdy-cdxbdy)) + float64(blift*(cdxady-adxcdy)) + float64(clift*(adxbdy-bdxady))
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift + (math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	if bound := predInCircleBound * permanent; det > bound || -det > bound {
+		return det
+	}
+	eadx, ebdx, ecdx := expDiff(a.X, d.X), expDiff(b.X, d.X), expDiff(c.X, d.X)
+	eady, ebdy, ecdy := expDiff(a.Y, d.Y), expDiff(b.Y, d.Y), expDiff(c.Y, d.Y)
+	bc := ebdx.mul(ecdy).sub(ecdx.mul(ebdy))
+	ca := ecdx.mul(eady).sub(eadx.mul(ecdy))
+	ab := eadx.mul(ebdy).sub(ebdx.mul(eady))
+	lift := func(x, y expansion) expansion { return x.mul(x).add(y.mul(y)) }
+	return lift(eadx, eady).mul(bc).add(lift(ebdx, ebdy).mul(ca)).add(lift(ecdx, ecdy).mul(ab)).estimate()
+}
+
+//	Returns a positive value if `e` lies inside the sphere through `a`, `b`, `c` and `d`, a negative value if outside,
+//	or 0 if on it, provided that `Orient3D(a, b, c, d)` is positive (the signs are reversed otherwise).
+//	The sign is always exact, as for `Orient2D`.
+func InSphere(a, b, c, d, e *Vec3) float64 {
+	aex, bex, cex, dex := a.X-e.X, b.X-e.X, c.X-e.X, d.X-e.X
+	aey, bey, cey, dey := a.Y-e.Y, b.Y-e.Y, c.Y-e.Y, d.Y-e.Y
+	aez, bez, cez, dez := a.Z-e.Z, b.Z-e.Z, c.Z-e.Z, d.Z-e.Z
+	aexbey, bexaey := float64(aex*bey), float64(bex*aey)
+	bexcey, cexbey := float64(bex*cey), float64(cex*bey)
+	cexdey, dexcey := float64(cex*dey), float64(dex*cey)
+	dexaey, aexdey := float64(dex*aey), float64(aex*dey)
+	aexcey, cexaey := float64(aex*cey), float64(cex*aey)
+	bexdey, dexbey := float64(bex*dey), float64(dex*bey)
+	ab, bc, cd, da, ac, bd := aexbey-bexaey, bexcey-cexbey, cexdey-dexcey, dexaey-aexdey, aexcey-cexaey, bexdey-dexbey
+	abc := float64(aez*bc) - float64(bez*ac) + float64(cez*ab)
+	bcd := float64(bez*cd) - float64(cez*bd) + float64(dez*bc)
+	cda := float64(cez*da) + float64(dez*ac) + float64(aez*cd)
+	dab := float64(dez*ab) + float64(aez*bd) + float64(bez*da)
+	alift := float64(aex*aex) + float64(aey*aey) + float64(aez*aez)
+	blift := float64(bex*bex) + float64(bey*bey) + float64(bez*bez)
+	clift := float64(cex*cex) + float64(cey*cey) + float64(cez*cez)
+	dlift := float64(dex*dex) + float64(dey*dey) + float64(dez*dez)
+	det := (float64(dlift*abc) - float64(clift*dab)) + (float64(blift*cda) - float64(alift*bcd))
+	az, bz, cz, dz := math.Abs(aez), math.Abs(bez), math.Abs(cez), math.Abs(dez)
+	pab, pbc, pcd := math.Abs(aexbey)+math.Abs(bexaey), math.Abs(bexcey)+math.Abs(cexbey), math.Abs(cexdey)+math.Abs(dexcey)
+	pda, pac, pbd := math.Abs(dexaey)+math.Abs(aexdey), math.Abs(aexcey)+math.Abs(cexaey), math.Abs(bexdey)+math.Abs(dexbey)
+	permanent := (pcd*bz+pbd*cz+pbc*dz)*alift + (pda*cz+pac*dz+pcd*az)*blift +
+		(pab*dz+pbd*az+pda*bz)*clift + (pbc*az+pac*bz+pab*cz)*dlift
+	if bound := predInSphereBound * permanent; det > bound || -det > bound {
+		return det
+	}
+	eaex, ebex, ecex, edex := expDiff(a.X, e.X), expDiff(b.X, e.X), expDiff(c.X, e.X), expDiff(d.X, e.X)
+	eaey, ebey, ecey, edey := expDiff(a.Y, e.Y), expDiff(b.Y, e.Y), expDiff(c.Y, e.Y), expDiff(d.Y, e.Y)
+	eaez, ebez, ecez, edez := expDiff(a.Z, e.Z), expDiff(b.Z, e.Z), expDiff(c.Z, e.Z), expDiff(d.Z, e.Z)
+	eab, ebc := eaex.mul(ebey).sub(ebex.mul(eaey)), ebex.mul(ecey).sub(ecex.mul(ebey))
+	ecd, eda := ecex.mul(edey).sub(edex.mul(ecey)), edex.mul(eaey).sub(eaex.mul(edey))
+	eac, ebd := eaex.mul(ecey).sub(ecex.mul(eaey)), ebex.mul(edey).sub(edex.mul(ebey))
+	eabc := eaez.mul(ebc).sub(ebez.mul(eac)).add(ecez.mul(eab))
+	ebcd := ebez.mul(ecd).sub(ecez.mul(ebd)).add(edez.mul(ebc))
+	ecda := ecez.mul(eda).add(edez.mul(eac)).add(eaez.mul(ecd))
+	edab := edez.mul(eab).add(eaez.mul(ebd)).add(ebez.mul(eda))
+	lift := func(x, y, z expansion) expansion { return x.mul(x).add(y.mul(y)).add(z.mul(z)) }
+	return lift(edex, edey, edez).mul(eabc).sub(lift(ecex, ecey, ecez).mul(edab)).
+		add(lift(ebex, ebey, ebez).mul(ecda)).sub(lift(eaex, eaey, eaez).mul(ebcd)).estimate()
+}
+
+//	A floating-point expansion: an exact sum of non-overlapping components, ordered by increasing magnitude.
+type expansion []float64
+
+//	Returns the exact difference `a - b` as an expansion.
+func expDiff(a, b float64) expansion {
+	x, y := twoSum(a, -b)
+	return expansion{y, x}.compact()
+}
+
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	y = (a - av) + (b - bv)
+	return
+}
+
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	y = math.FMA(a, b, -x)
+	return
+}
+
+//	Returns `me` without zero components.
+func (me expansion) compact() (e expansion) {
+	for _, v := range me {
+		if v != 0 {
+			e = append(e, v)
+		}
+	}
+	return
+}
+
+//	Returns the exact sum of `me` and `f`, by growing `me` with each component of `f` in turn.
+func (me expansion) add(f expansion) expansion {
+	e := me
+	for _, b := range f {
+		g := make(expansion, 0, len(e)+1)
+		q := b
+		for _, v := range e {
+			var h float64
+			if q, h = twoSum(q, v); h != 0 {
+				g = append(g, h)
+			}
+		}
+		if q != 0 {
+			g = append(g, q)
+		}
+		e = g
+	}
+	return e
+}
+
+//	Returns the approximate value of `me`, which has the exact sign.
+func (me expansion) estimate() (sum float64) {
+	for _, v := range me {
+		sum += v
+	}
+	return
+}
+
+//	Returns the exact product of `me` and `f`.
+func (me expansion) mul(f expansion) (p expansion) {
+	for _, b := range f {
+		p = p.add(me.scale(b))
+	}
+	return
+}
+
+//	Returns the exact product of `me` and `b`.
+func (me expansion) scale(b float64) (e expansion) {
+	if len(me) == 0 {
+		return
+	}
+	q, h := twoProduct(me[0], b)
+	if h != 0 {
+		e = append(e, h)
+	}
+	for _, v := range me[1:] {
+		t1, t0 := twoProduct(v, b)
+		var s float64
+		if s, h = twoSum(q, t0); h != 0 {
+			e = append(e, h)
+		}
+		//	fast two-sum, as `|t1| >= |s|`
+		q = t1 + s
+		if h = s - (q - t1); h != 0 {
+			e = append(e, h)
+		}
+	}
+	if q != 0 {
+		e = append(e, q)
+	}
+	return
+}
+
+//	Returns the exact difference of `me` and `f`.
+func (me expansion) sub(f expansion) expansion {
+	neg := make(expansion, len(f))
+	for i, v := range f {
+		neg[i] = -v
+	}
+	return me.add(neg)
+}
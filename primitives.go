@@ -0,0 +1,321 @@
+package unum
+
+import (
+	"math"
+)
+
+//	All primitive constructors below return meshes centered at the origin with `Y` up, with `Positions`, `Normals`,
+//	`UVs` and outward-facing (counter-clockwise) triangles, but no `Tangents`. Curved surfaces get smooth normals,
+//	while distinct flat faces (such as the caps of a cylinder) get their own vertices.
+
+//	Returns a new box `*Mesh` with the specified `halfExtents`, each face divided into `segments` x `segments` quads,
+//	and each face mapped to the full UV square.
+func NewMeshBox(halfExtents *Vec3, segments int) (me *Mesh) {
+	me = new(Mesh)
+	h, s := *halfExtents, halfExtents.Scaled(2)
+	segments = imax(segments, 1)
+	me.addPatch(&Vec3{h.X, -h.Y, h.Z}, &Vec3{0, 0, -s.Z}, &Vec3{0, s.Y, 0}, segments, segments)
+	me.addPatch(&Vec3{-h.X, -h.Y, -h.Z}, &Vec3{0, 0, s.Z}, &Vec3{0, s.Y, 0}, segments, segments)
+	me.addPatch(&Vec3{-h.X, h.Y, h.Z}, &Vec3{s.X, 0, 0}, &Vec3{0, 0, -s.Z}, segments, segments)
+	me.addPatch(&Vec3{-h.X, -h.Y, -h.Z}, &Vec3{s.X, 0, 0}, &Vec3{0, 0, s.Z}, segments, segments)
+	me.addPatch(&Vec3{-h.X, -h.Y, h.Z}, &Vec3{s.X, 0, 0}, &Vec3{0, s.Y, 0}, segments, segments)
+	me.addPatch(&Vec3{h.X, -h.Y, -h.Z}, &Vec3{-s.X, 0, 0}, &Vec3{0, s.Y, 0}, segments, segments)
+	return
+}
+
+//	Returns a new capsule `*Mesh`: a cylinder with the specified `radius` whose caps are hemispheres centered
+//	`height` apart (so that the total height is `height + 2 * radius`), with `segments` around and `rings`
+//	latitude bands per hemisphere. V runs from 0 at the bottom to 1 at the top.
+func NewMeshCapsule(radius, height float64, segments, rings int) (me *Mesh) {
+	me = new(Mesh)
+	rings = imax(rings, 1)
+	total, profile := height+2*radius, make([]latheVertex, 0, 2*rings+2)
+	for hemi, offset := range []float64{-height / 2, height / 2} {
+		for k := 0; k <= rings; k++ {
+			//	polar angle from +Y: from Pi to Pi/2 for the bottom hemisphere, then from Pi/2 to 0 for the top
+			theta := math.Pi/2*float64(1-hemi) + math.Pi/2*(1-float64(k)/float64(rings))
+			sin, cos := math.Sincos(theta)
+			if (hemi == 0 && k == 0) || (hemi == 1 && k == rings) {
+				//	exactly on the axis, so that the triangles at the poles degenerate cleanly
+				sin = 0
+			}
+			y := offset + radius*cos
+			profile = append(profile, latheVertex{radius * sin, y, sin, cos, (y + total/2) / total})
+		}
+	}
+	me.addLathe(profile, segments, 0)
+	return
+}
+
+//	Returns a new cone `*Mesh` with the specified base `radius` and `height`, its base at `-height / 2` and apex at
+//	`height / 2`, with `segments` around and `stacks` along the height, and a flat base unless `cap` is `false`.
+func NewMeshCone(radius, height float64, segments, stacks int, cap bool) (me *Mesh) {
+	me = new(Mesh)
+	me.addFrustum(radius, 0, height, segments, stacks, cap, false)
+	return
+}
+
+//	Returns a new cylinder `*Mesh` with the specified `radius` and `height`, with `segments` around and `stacks`
+//	along the height, and flat ends unless `caps` is `false`.
+func NewMeshCylinder(radius, height float64, segments, stacks int, caps bool) (me *Mesh) {
+	me = new(Mesh)
+	me.addFrustum(radius, radius, height, segments, stacks, caps, caps)
+	return
+}
+
+//	Returns a new disc `*Mesh` in the XZ plane facing +Y with the specified `radius`, `segments` around and `rings`
+//	from the rim to the center, mapped to the UV square as for `NewMeshPlane`.
+func NewMeshDisc(radius float64, segments, rings int) (me *Mesh) {
+	me = new(Mesh)
+	me.addDisc(0, radius, segments, rings, true)
+	return
+}
+
+//	Returns a new sphere `*Mesh` with the specified `radius`, made by `subdivisions` times splitting each triangle
+//	of an icosahedron into 4, for evenly sized triangles (unlike the slivers near the poles of `NewMeshUVSphere`).
+//	UVs are the same spherical mapping as for `NewMeshUVSphere`, with vertices duplicated along the seam and at the poles.
+func NewMeshIcosphere(radius float64, subdivisions int) (me *Mesh) {
+	t := (1 + math.Sqrt(5)) / 2
+	points := []Vec3{{-1, t, 0}, {1, t, 0}, {-1, -t, 0}, {1, -t, 0}, {0, -1, t}, {0, 1, t}, {0, -1, -t}, {0, 1, -t}, {t, 0, -1}, {t, 0, 1}, {-t, 0, -1}, {-t, 0, 1}}
+	faces := []uint32{0, 11, 5, 0, 5, 1, 0, 1, 7, 0, 7, 10, 0, 10, 11, 1, 5, 9, 5, 11, 4, 11, 10, 2, 10, 7, 6, 7, 1, 8,
+		3, 9, 4, 3, 4, 2, 3, 2, 6, 3, 6, 8, 3, 8, 9, 4, 9, 5, 2, 4, 11, 6, 2, 10, 8, 6, 7, 9, 8, 1}
+	for i := range points {
+		points[i].Normalize()
+	}
+	for s := 0; s < subdivisions; s++ {
+		mids, next := map[[2]uint32]uint32{}, make([]uint32, 0, 4*len(faces))
+		mid := func(a, b uint32) uint32 {
+			if a > b {
+				a, b = b, a
+			}
+			i, ok := mids[[2]uint32{a, b}]
+			if !ok {
+				i = uint32(len(points))
+				mids[[2]uint32{a, b}] = i
+				p := points[a].Added(&points[b])
+				p.Normalize()
+				points = append(points, *p)
+			}
+			return i
+		}
+		for f := 0; f < len(faces); f += 3 {
+			a, b, c := faces[f], faces[f+1], faces[f+2]
+			ab, bc, ca := mid(a, b), mid(b, c), mid(c, a)
+			next = append(next, a, ab, ca, b, bc, ab, c, ca, bc, ab, bc, ca)
+		}
+		faces = next
+	}
+	me = &Mesh{Indices: make([]uint32, len(faces))}
+	type vertKey struct {
+		i uint32
+		u float64
+	}
+	verts := map[vertKey]uint32{}
+	for f := 0; f < len(faces); f += 3 {
+		var us [3]float64
+		var pole [3]bool
+		for k := 0; k < 3; k++ {
+			p := &points[faces[f+k]]
+			if pole[k] = p.X == 0 && p.Z == 0; !pole[k] {
+				if us[k] = math.Atan2(-p.Z, p.X) / (2 * math.Pi); us[k] < 0 {
+					us[k]++
+				}
+			}
+		}
+		//	unwrap the seam, then give poles the mean U of the other 2 corners
+		lo, hi, n := 1.0, 0.0, 0
+		for k := 0; k < 3; k++ {
+			if !pole[k] {
+				lo, hi = math.Min(lo, us[k]), math.Max(hi, us[k])
+			}
+		}
+		for k := 0; k < 3; k++ {
+			if !pole[k] && hi-lo > 0.5 && us[k] < 0.5 {
+				us[k]++
+			}
+		}
+		sum := 0.0
+		for k := 0; k < 3; k++ {
+			if !pole[k] {
+				sum, n = sum+us[k], n+1
+			}
+		}
+		for k := 0; k < 3; k++ {
+			if pole[k] {
+				us[k] = sum / float64(n)
+			}
+			key := vertKey{faces[f+k], us[k]}
+			i, ok := verts[key]
+			if !ok {
+				p := &points[key.i]
+				i = uint32(len(me.Positions))
+				verts[key] = i
+				me.Positions = append(me.Positions, *p.Scaled(radius))
+				me.Normals = append(me.Normals, *p)
+				me.UVs = append(me.UVs, Vec2{us[k], 0.5 + math.Asin(Clamp(p.Y, -1, 1))/math.Pi})
+			}
+			me.Indices[f+k] = i
+		}
+	}
+	return
+}
+
+//	Returns a new plane `*Mesh` in the XZ plane facing +Y with the specified `width` (along X) and `depth`
+//	(along Z), divided into `segmentsX` x `segmentsZ` quads. U runs along +X and V along -Z.
+func NewMeshPlane(width, depth float64, segmentsX, segmentsZ int) (me *Mesh) {
+	me = new(Mesh)
+	me.addPatch(&Vec3{-width / 2, 0, depth / 2}, &Vec3{width, 0, 0}, &Vec3{0, 0, -depth}, imax(segmentsX, 1), imax(segmentsZ, 1))
+	return
+}
+
+//	Returns a new torus `*Mesh` around the Y axis, whose tube of `minorRadius` circles at `majorRadius` from the
+//	center, with `majorSegments` around the Y axis and `minorSegments` around the tube.
+func NewMeshTorus(majorRadius, minorRadius float64, majorSegments, minorSegments int) (me *Mesh) {
+	me = new(Mesh)
+	minorSegments = imax(minorSegments, 3)
+	profile := make([]latheVertex, minorSegments+1)
+	for k := range profile {
+		//	counter-clockwise around the tube starting from its outermost point, which keeps the normals outward
+		v := float64(k) / float64(minorSegments)
+		sin, cos := math.Sincos(2 * math.Pi * v)
+		profile[k] = latheVertex{majorRadius + minorRadius*cos, minorRadius * sin, cos, sin, v}
+	}
+	//	close the tube exactly
+	profile[minorSegments] = profile[0]
+	profile[minorSegments].v = 1
+	me.addLathe(profile, majorSegments, 0)
+	return
+}
+
+//	Returns a new sphere `*Mesh` with the specified `radius`, `segments` around the Y axis and `rings` latitude bands.
+//	U runs around the Y axis (starting at +X) and V from 0 at the bottom to 1 at the top.
+func NewMeshUVSphere(radius float64, segments, rings int) (me *Mesh) {
+	me = new(Mesh)
+	rings = imax(rings, 2)
+	profile := make([]latheVertex, rings+1)
+	for k := range profile {
+		v := float64(k) / float64(rings)
+		sin, cos := math.Sincos(math.Pi * (1 - v))
+		if k == 0 || k == rings {
+			//	exactly on the axis, so that the triangles at the poles degenerate cleanly
+			sin = 0
+		}
+		profile[k] = latheVertex{radius * sin, radius * cos, sin, cos, v}
+	}
+	me.addLathe(profile, segments, 0)
+	return
+}
+
+//	A point of the profile curve revolved by `Mesh.addLathe`: its distance from the Y axis and its height,
+//	the radial and Y parts of its normal, and its V texture coordinate.
+type latheVertex struct {
+	radius, y, normalRadius, normalY, v float64
+}
+
+//	Adds a flat disc at height `y` with the specified `radius`, facing +Y if `up`, or else -Y.
+func (me *Mesh) addDisc(y, radius float64, segments, rings int, up bool) {
+	rings = imax(rings, 1)
+	profile, normalY := make([]latheVertex, rings+1), 1.0
+	if !up {
+		normalY = -1
+	}
+	for k := range profile {
+		//	from the rim inwards when facing up, or outwards when facing down, for outward winding
+		f := float64(k) / float64(rings)
+		if up {
+			f = 1 - f
+		}
+		profile[k] = latheVertex{radius * f, y, 0, normalY, 0}
+	}
+	me.addLathe(profile, segments, radius)
+}
+
+//	Adds the side of a truncated cone from `-height / 2` (with `bottomRadius`) to `height / 2` (with `topRadius`),
+//	and optionally its flat ends.
+func (me *Mesh) addFrustum(bottomRadius, topRadius, height float64, segments, stacks int, bottomCap, topCap bool) {
+	stacks = imax(stacks, 1)
+	profile := make([]latheVertex, stacks+1)
+	slope := Vec2{height, bottomRadius - topRadius}
+	slope.Normalize()
+	for k := range profile {
+		v := float64(k) / float64(stacks)
+		profile[k] = latheVertex{bottomRadius + (topRadius-bottomRadius)*v, height * (v - 0.5), slope.X, slope.Y, v}
+	}
+	me.addLathe(profile, segments, 0)
+	if bottomCap && bottomRadius > 0 {
+		me.addDisc(-height/2, bottomRadius, segments, 1, false)
+	}
+	if topCap && topRadius > 0 {
+		me.addDisc(height/2, topRadius, segments, 1, true)
+	}
+}
+
+//	Adds the surface of revolution of `profile` around the Y axis with `segments` around, which faces outwards if
+//	the `profile` runs bottom-to-top on its outer side. U runs around the Y axis starting at +X, unless `planarRadius`
+//	is positive, in which case UVs map the XZ square of that half size as for `NewMeshPlane`. Triangles degenerated
+//	by profile points on the axis are omitted.
+func (me *Mesh) addLathe(profile []latheVertex, segments int, planarRadius float64) {
+	segments = imax(segments, 3)
+	base := uint32(len(me.Positions))
+	for _, lv := range profile {
+		for c := 0; c <= segments; c++ {
+			u := float64(c) / float64(segments)
+			sin, cos := math.Sincos(2 * math.Pi * u)
+			if c == segments {
+				sin, cos = 0, 1
+			}
+			pos := Vec3{lv.radius * cos, lv.y, -lv.radius * sin}
+			uv := Vec2{u, lv.v}
+			if planarRadius > 0 {
+				uv.Set(0.5+pos.X/(2*planarRadius), 0.5-pos.Z/(2*planarRadius))
+			}
+			me.Positions = append(me.Positions, pos)
+			me.Normals = append(me.Normals, Vec3{lv.normalRadius * cos, lv.normalY, -lv.normalRadius * sin})
+			me.UVs = append(me.UVs, uv)
+		}
+	}
+	row := uint32(segments + 1)
+	for k := uint32(0); k+1 < uint32(len(profile)); k++ {
+		for c := uint32(0); c < uint32(segments); c++ {
+			i0 := base + k*row + c
+			me.addTriangleUnlessDegenerate(i0, i0+1, i0+row+1)
+			me.addTriangleUnlessDegenerate(i0, i0+row+1, i0+row)
+		}
+	}
+}
+
+//	Adds the flat parallelogram spanned by `du` and `dv` from `origin`, divided into `segU` x `segV` quads,
+//	facing along `du` x `dv`.
+func (me *Mesh) addPatch(origin, du, dv *Vec3, segU, segV int) {
+	base, n := uint32(len(me.Positions)), du.CrossNormalized(dv)
+	for j := 0; j <= segV; j++ {
+		for i := 0; i <= segU; i++ {
+			u, v := float64(i)/float64(segU), float64(j)/float64(segV)
+			pos := origin.Added(du.Scaled(u))
+			pos.Add(dv.Scaled(v))
+			me.Positions = append(me.Positions, *pos)
+			me.Normals = append(me.Normals, *n)
+			me.UVs = append(me.UVs, Vec2{u, v})
+		}
+	}
+	row := uint32(segU + 1)
+	for j := uint32(0); j < uint32(segV); j++ {
+		for i := uint32(0); i < uint32(segU); i++ {
+			i0 := base + j*row + i
+			me.Indices = append(me.Indices, i0, i0+1, i0+row+1, i0, i0+row+1, i0+row)
+		}
+	}
+}
+
+func (me *Mesh) addTriangleUnlessDegenerate(a, b, c uint32) {
+	if pa, pb, pc := me.Positions[a], me.Positions[b], me.Positions[c]; pa != pb && pb != pc && pc != pa {
+		me.Indices = append(me.Indices, a, b, c)
+	}
+}
+
+func imax(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,175 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Describes one grid cell traversed by `Ray.Voxels`.
+type VoxelHit struct {
+	//	The integer coordinates of the cell.
+	Cell [3]int
+
+	//	The ray parameters at which the ray enters and exits the cell.
+	TEnter, TExit float64
+
+	//	The outward normal of the cell face through which the ray entered, or all 0 for the cell containing `Origin`.
+	Normal [3]int
+}
+
+//	Walks `me` through the unbounded grid of cubic cells with `cellSize` (cell `(i, j, k)` spans from
+//	`(i, j, k) * cellSize` to `(i+1, j+1, k+1) * cellSize`) in the order traversed, using the DDA of
+//	Amanatides and Woo. Calls `visit` for each cell entered at most at parameter `maxT`, until `visit` returns `false`.
+//
+//	Where the ray passes exactly through an edge or corner, only one of the adjacent cells is visited per step.
+func (me *Ray) Voxels(cellSize, maxT float64, visit func(hit *VoxelHit) bool) {
+	var hit VoxelHit
+	var step [3]int
+	var tNext, tDelta [3]float64
+	inv := 1 / cellSize
+	for a := 0; a < 3; a++ {
+		o, d := me.Origin.at(a)*inv, me.Dir.at(a)*inv
+		hit.Cell[a] = int(math.Floor(o))
+		switch {
+		case d > 0:
+			step[a], tNext[a], tDelta[a] = 1, (float64(hit.Cell[a]+1)-o)/d, 1/d
+		case d < 0:
+			step[a], tNext[a], tDelta[a] = -1, (float64(hit.Cell[a])-o)/d, -1/d
+		default:
+			tNext[a], tDelta[a] = math.Inf(1), math.Inf(1)
+		}
+	}
+	for hit.TEnter <= maxT {
+		a := 0
+		if tNext[1] < tNext[a] {
+			a = 1
+		}
+		if tNext[2] < tNext[a] {
+			a = 2
+		}
+		if hit.TExit = tNext[a]; !visit(&hit) || math.IsInf(hit.TExit, 1) {
+			return
+		}
+		hit.Cell[a] += step[a]
+		hit.TEnter, tNext[a] = tNext[a], tNext[a]+tDelta[a]
+		hit.Normal = [3]int{}
+		hit.Normal[a] = -step[a]
+	}
+}
+
+//	Calls `visit` for each cell on the 8-connected Bresenham line from `(x0, y0)` to `(x1, y1)` (both included),
+//	in order, until `visit` returns `false`.
+func BresenhamLine2(x0, y0, x1, y1 int, visit func(x, y int) bool) {
+	dx, dy := absInt(x1-x0), -absInt(y1-y0)
+	sx, sy := signInt(x1-x0), signInt(y1-y0)
+	err := dx + dy
+	for visit(x0, y0) && (x0 != x1 || y0 != y1) {
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+//	Calls `visit` for each cell on the 26-connected Bresenham line from `(x0, y0, z0)` to `(x1, y1, z1)`
+//	(both included), in order, until `visit` returns `false`.
+func BresenhamLine3(x0, y0, z0, x1, y1, z1 int, visit func(x, y, z int) bool) {
+	p, d, s := [3]int{x0, y0, z0}, [3]int{absInt(x1 - x0), absInt(y1 - y0), absInt(z1 - z0)}, [3]int{signInt(x1 - x0), signInt(y1 - y0), signInt(z1 - z0)}
+	//	step along the driving (longest) axis, accumulating errors for the other 2
+	m := 0
+	if d[1] > d[m] {
+		m = 1
+	}
+	if d[2] > d[m] {
+		m = 2
+	}
+	a, b := (m+1)%3, (m+2)%3
+	ea, eb := 2*d[a]-d[m], 2*d[b]-d[m]
+	for i := 0; i <= d[m]; i++ {
+		if !visit(p[0], p[1], p[2]) {
+			return
+		}
+		if ea > 0 {
+			p[a] += s[a]
+			ea -= 2 * d[m]
+		}
+		if eb > 0 {
+			p[b] += s[b]
+			eb -= 2 * d[m]
+		}
+		ea, eb = ea+2*d[a], eb+2*d[b]
+		p[m] += s[m]
+	}
+}
+
+//	Calls `visit` once for each cell on the midpoint (Bresenham) circle around `(cx, cy)` with `radius`,
+//	until `visit` returns `false`. The cells are visited octant by octant, not in contour order.
+func BresenhamCircle(cx, cy, radius int, visit func(x, y int) bool) {
+	if radius <= 0 {
+		if radius == 0 {
+			visit(cx, cy)
+		}
+		return
+	}
+	for x, y, err := radius, 0, 1-radius; x >= y; y++ {
+		var pts [][2]int
+		switch {
+		case y == 0:
+			pts = [][2]int{{x, 0}, {0, x}, {-x, 0}, {0, -x}}
+		case x == y:
+			pts = [][2]int{{x, x}, {-x, x}, {-x, -x}, {x, -x}}
+		default:
+			pts = [][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}}
+		}
+		for _, p := range pts {
+			if !visit(cx+p[0], cy+p[1]) {
+				return
+			}
+		}
+		if err < 0 {
+			err += 2*(y+1) + 1
+		} else {
+			x--
+			err += 2*(y+1-x) + 1
+		}
+	}
+}
+
+//	Calls `visit` once for each cell on the surface of the voxelized sphere around `(cx, cy, cz)` with `radius`
+//	(the 3D counterpart of `BresenhamCircle`), until `visit` returns `false`: the cells whose centers lie within
+//	`radius + 0.5` of the center but which have a face neighbor that doesn't. The resulting shell is watertight.
+func BresenhamSphere(cx, cy, cz, radius int, visit func(x, y, z int) bool) {
+	r2 := (float64(radius) + 0.5) * (float64(radius) + 0.5)
+	in := func(x, y, z int) bool { return float64(x*x+y*y+z*z) <= r2 }
+	for z := -radius; z <= radius; z++ {
+		for y := -radius; y <= radius; y++ {
+			for x := -radius; x <= radius; x++ {
+				if in(x, y, z) && !(in(x-1, y, z) && in(x+1, y, z) && in(x, y-1, z) && in(x, y+1, z) && in(x, y, z-1) && in(x, y, z+1)) {
+					if !visit(cx+x, cy+y, cz+z) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func signInt(v int) int {
+	if v < 0 {
+		return -1
+	} else if v > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents a ray as the set of all points `Origin + Dir * t` for `t >= 0`.
+//
+//	All ray parameters `t` are in units of `Dir`, so they are distances only if `Dir` is unit-length.
+type Ray struct {
+	//	The starting point.
+	Origin Vec3
+
+	//	The direction, not necessarily unit-length.
+	Dir Vec3
+}
+
+//	Returns a new `*Ray` starting at `origin` and pointing towards `target`, with unit-length `Dir`.
+func NewRay(origin, target *Vec3) (me *Ray) {
+	me = &Ray{Origin: *origin}
+	me.Dir.SetFromSub(target, origin)
+	me.Dir.NormalizeSafe()
+	return
+}
+
+//	Returns the point at parameter `t` along `me`.
+func (me *Ray) At(t float64) *Vec3 {
+	return me.Dir.ScaledAdded(t, &me.Origin)
+}
+
+//	Intersects `me` with `aabb` using the slab method. If `ok`, the ray overlaps `aabb` from parameter `tMin`
+//	(0 if `Origin` lies within `aabb`) to `tMax`.
+func (me *Ray) IntersectAABB(aabb *AABB) (tMin, tMax float64, ok bool) {
+	tMin, tMax = 0, math.Inf(1)
+	for a := 0; a < 3; a++ {
+		o, d, lo, hi := me.Origin.at(a), me.Dir.at(a), aabb.Min.at(a), aabb.Max.at(a)
+		if d == 0 {
+			if o < lo || o > hi {
+				return
+			}
+			continue
+		}
+		t0, t1 := (lo-o)/d, (hi-o)/d
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		if tMin, tMax = math.Max(tMin, t0), math.Min(tMax, t1); tMin > tMax {
+			return
+		}
+	}
+	ok = true
+	return
+}
+
+//	Intersects `me` with `plane` (from either side), returning the parameter `t` of the intersection point.
+func (me *Ray) IntersectPlane(plane *Plane) (t float64, ok bool) {
+	if d := plane.Normal.Dot(&me.Dir); d != 0 {
+		t = -plane.SignedDistance(&me.Origin) / d
+		ok = t >= 0
+	}
+	return
+}
+
+//	Intersects `me` with the sphere at `center` with `radius`, returning the parameter `t` of the first intersection
+//	point (0 if `Origin` lies within the sphere).
+func (me *Ray) IntersectSphere(center *Vec3, radius float64) (t float64, ok bool) {
+	var m Vec3
+	m.SetFromSub(&me.Origin, center)
+	a, b, c := me.Dir.Dot(&me.Dir), m.Dot(&me.Dir), m.Dot(&m)-radius*radius
+	if c <= 0 {
+		return 0, true
+	}
+	if b > 0 || a == 0 {
+		return
+	}
+	if disc := b*b - a*c; disc >= 0 {
+		t, ok = (-b-math.Sqrt(disc))/a, true
+	}
+	return
+}
+
+//	Intersects `me` with the triangle `a`, `b`, `c` (from either side) using the Moeller-Trumbore algorithm,
+//	returning the parameter `t` of the intersection point plus its barycentric coordinates `u` (weight of `b`)
+//	and `v` (weight of `c`).
+func (me *Ray) IntersectTriangle(a, b, c *Vec3) (t, u, v float64, ok bool) {
+	var ab, ac, p, s, q Vec3
+	ab.SetFromSub(b, a)
+	ac.SetFromSub(c, a)
+	p.SetFromCrossOf(&me.Dir, &ac)
+	det := ab.Dot(&p)
+	if math.Abs(det) <= Epsilon*ab.Magnitude()*ac.Magnitude()*me.Dir.Magnitude() {
+		return
+	}
+	inv := 1 / det
+	s.SetFromSub(&me.Origin, a)
+	if u = s.Dot(&p) * inv; u < 0 || u > 1 {
+		return
+	}
+	q.SetFromCrossOf(&s, &ab)
+	if v = me.Dir.Dot(&q) * inv; v < 0 || u+v > 1 {
+		return
+	}
+	t = ac.Dot(&q) * inv
+	ok = t >= 0
+	return
+}
@@ -0,0 +1,153 @@
+package unum
+
+//	Represents the state of a rigid body: position, orientation and linear/angular momentum,
+//	plus its mass properties, accumulated forces and derived (world-space) quantities.
+//
+//	The body's local origin is its center of mass. After modifying `Pos`, `Rot`, `LinMom` or `AngMom` directly,
+//	call `UpdateDerived` before reading `Vel`, `AngVel`, `RotMat` or `InvInertiaWorld`.
+type RigidBody struct {
+	//	World-space position of the center of mass.
+	Pos Vec3
+
+	//	World-space orientation, a unit quaternion.
+	Rot Quat
+
+	//	Linear momentum `mass * Vel` and angular momentum `inertia * AngVel`, both in world space.
+	LinMom, AngMom Vec3
+
+	//	Inverse mass. 0 represents an immovable body.
+	InvMass float64
+
+	//	Inverse inertia tensor in body space. All-zero represents a body that cannot rotate.
+	InvInertiaBody Mat3
+
+	//	Accumulated world-space force and torque, applied and cleared by `Integrate`.
+	Force, Torque Vec3
+
+	//	Derived from the above by `UpdateDerived`: linear and angular velocity.
+	Vel, AngVel Vec3
+
+	//	Derived from the above by `UpdateDerived`: rotation matrix of `Rot` and world-space inverse inertia tensor.
+	RotMat, InvInertiaWorld Mat3
+}
+
+//	Returns a new `*RigidBody` at the origin with identity orientation, the specified `mass` and body-space `inertia` tensor.
+//	A `mass` of 0 creates an immovable body; a `nil` or singular `inertia` creates a body that cannot rotate.
+func NewRigidBody(mass float64, inertia *Mat3) (me *RigidBody) {
+	me = &RigidBody{Rot: Quat_Identity()}
+	me.SetMass(mass, inertia)
+	return
+}
+
+//	Adds the world-space `force` acting at the center of mass.
+func (me *RigidBody) AddForce(force *Vec3) {
+	me.Force.Add(force)
+}
+
+//	Adds the world-space `force` acting at the world-space `point`, which also induces torque.
+func (me *RigidBody) AddForceAtPoint(force, point *Vec3) {
+	var r, t Vec3
+	me.Force.Add(force)
+	r.SetFromSub(point, &me.Pos)
+	t.SetFromCrossOf(&r, force)
+	me.Torque.Add(&t)
+}
+
+//	Adds the world-space `torque`.
+func (me *RigidBody) AddTorque(torque *Vec3) {
+	me.Torque.Add(torque)
+}
+
+//	Applies the world-space `impulse` at the world-space `point`, immediately changing both momenta and velocities.
+func (me *RigidBody) ApplyImpulse(impulse, point *Vec3) {
+	var r, t Vec3
+	me.LinMom.Add(impulse)
+	r.SetFromSub(point, &me.Pos)
+	t.SetFromCrossOf(&r, impulse)
+	me.AngMom.Add(&t)
+	me.updateVelocities()
+}
+
+//	Zeroes the accumulated `Force` and `Torque`.
+func (me *RigidBody) ClearForces() {
+	me.Force.Clear()
+	me.Torque.Clear()
+}
+
+//	Advances the state of `me` by the time step `h` using semi-implicit Euler integration:
+//	momenta are updated from the accumulated force and torque first, then position and orientation
+//	are advanced with the resulting velocities. The accumulators are cleared afterwards.
+func (me *RigidBody) Integrate(h float64) {
+	me.LinMom.SetFromAddScaled(&me.LinMom, &me.Force, h)
+	me.AngMom.SetFromAddScaled(&me.AngMom, &me.Torque, h)
+	me.updateVelocities()
+	me.Pos.SetFromAddScaled(&me.Pos, &me.Vel, h)
+	me.IntegrateRot(&me.AngVel, h)
+	me.UpdateDerived()
+	me.ClearForces()
+}
+
+//	Advances `Rot` by the world-space angular velocity `angVel` over the time step `h`
+//	via `q' = q + h/2 * (angVel, 0) * q`, then re-normalizes it.
+func (me *RigidBody) IntegrateRot(angVel *Vec3, h float64) {
+	q, w := &me.Rot, Quat{Vec4{angVel.X, angVel.Y, angVel.Z, 0}}
+	dq := w.Mul(q)
+	dq.Scale(0.5 * h)
+	q.X, q.Y, q.Z, q.W = q.X+dq.X, q.Y+dq.Y, q.Z+dq.Z, q.W+dq.W
+	q.Normalize()
+}
+
+//	Returns the kinetic energy (linear plus rotational) of `me`.
+func (me *RigidBody) KineticEnergy() float64 {
+	return 0.5 * (me.LinMom.Dot(&me.Vel) + me.AngMom.Dot(&me.AngVel))
+}
+
+//	Returns the world-space position of the body-space `point`.
+func (me *RigidBody) LocalToWorld(point *Vec3) (pos *Vec3) {
+	pos = new(Vec3)
+	pos.MultMat3Vec3(&me.RotMat, point)
+	pos.Add(&me.Pos)
+	return
+}
+
+//	Sets the mass properties of `me`. A `mass` of 0 makes `me` immovable;
+//	a `nil` or singular body-space `inertia` tensor makes `me` unable to rotate.
+func (me *RigidBody) SetMass(mass float64, inertia *Mat3) {
+	if me.InvMass = 0; mass > 0 {
+		me.InvMass = 1 / mass
+	}
+	if inertia == nil || mass <= 0 || !me.InvInertiaBody.SetFromInverseOf(inertia) {
+		me.InvInertiaBody.Clear()
+	}
+	me.UpdateDerived()
+}
+
+//	Recomputes `RotMat`, `InvInertiaWorld`, `Vel` and `AngVel` from the current state of `me`.
+func (me *RigidBody) UpdateDerived() {
+	me.RotMat.SetFromQuat(&me.Rot)
+	me.InvInertiaWorld.SetFromRotated(&me.RotMat, &me.InvInertiaBody)
+	me.updateVelocities()
+}
+
+func (me *RigidBody) updateVelocities() {
+	me.Vel.SetFromScaled(&me.LinMom, me.InvMass)
+	me.AngVel.MultMat3Vec3(&me.InvInertiaWorld, &me.AngMom)
+}
+
+//	Returns the world-space velocity of the world-space `point` as if rigidly attached to `me`.
+func (me *RigidBody) VelocityAt(point *Vec3) (vel *Vec3) {
+	var r Vec3
+	r.SetFromSub(point, &me.Pos)
+	vel = me.AngVel.Cross(&r)
+	vel.Add(&me.Vel)
+	return
+}
+
+//	Returns the body-space position of the world-space `point`.
+func (me *RigidBody) WorldToLocal(point *Vec3) (pos *Vec3) {
+	var rt Mat3
+	rt.SetFromTransposeOf(&me.RotMat)
+	pos = point.Sub(&me.Pos)
+	pos.MultMat3(&rt)
+	return
+}
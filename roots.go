@@ -0,0 +1,346 @@
+package unum
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+//	The default maximum number of iterations used by `SolveBrent` and `SolveNewtonSafe` when `maxIter` is 0 or less.
+var RootsMaxIter = 100
+
+//	Returns the distinct real roots of `a*x*x + b*x + c = 0`, in ascending order.
+//
+//	Uses the cancellation-free form `q = -(b + sign(b)*sqrt(d))/2; x0 = q/a; x1 = c/q`.
+//	If `a` is 0, the linear equation `b*x + c = 0` is solved instead.
+func SolveQuadratic(a, b, c float64) (roots []float64) {
+	if a == 0 {
+		if b != 0 {
+			roots = append(roots, -c/b)
+		}
+		return
+	}
+	d := b*b - 4*a*c
+	switch {
+	case d < 0:
+	case d == 0:
+		roots = append(roots, -b/(2*a))
+	default:
+		q := -0.5 * (b + math.Copysign(math.Sqrt(d), b))
+		if x0, x1 := q/a, c/q; x0 < x1 {
+			roots = append(roots, x0, x1)
+		} else if x0 > x1 {
+			roots = append(roots, x1, x0)
+		} else {
+			//	`d` was too tiny to separate the roots in floating-point
+			roots = append(roots, x0)
+		}
+	}
+	return
+}
+
+//	Returns both (possibly complex or repeated) roots of `a*x*x + b*x + c = 0`.
+//
+//	If `a` is 0, at most the single root of the linear equation `b*x + c = 0` is returned.
+func SolveQuadraticComplex(a, b, c float64) (roots []complex128) {
+	if a == 0 {
+		if b != 0 {
+			roots = append(roots, complex(-c/b, 0))
+		}
+		return
+	}
+	return solveQuadraticC(complex(a, 0), complex(b, 0), complex(c, 0))
+}
+
+func solveQuadraticC(a, b, c complex128) []complex128 {
+	d := cmplx.Sqrt(b*b - 4*a*c)
+	//	pick the sign of `d` that avoids cancellation against `b`
+	if real(b)*real(d)+imag(b)*imag(d) < 0 {
+		d = -d
+	}
+	if q := -0.5 * (b + d); q != 0 {
+		return []complex128{q / a, c / q}
+	}
+	return []complex128{0, 0}
+}
+
+//	Returns the distinct real roots of `a*x*x*x + b*x*x + c*x + d = 0`, in ascending order.
+//
+//	Three real roots are computed with the trigonometric method, a single real root with
+//	Cardano's formula; all roots are then polished with a Newton step against the original cubic.
+//	If `a` is 0, `SolveQuadratic(b, c, d)` is returned instead.
+func SolveCubic(a, b, c, d float64) (roots []float64) {
+	if a == 0 {
+		return SolveQuadratic(b, c, d)
+	}
+	for _, r := range solveCubicC(a, b, c, d) {
+		if imag(r) == 0 {
+			roots = append(roots, real(r))
+		}
+	}
+	return polishRoots(roots, a, b, c, d)
+}
+
+//	Returns all 3 (possibly complex or repeated) roots of `a*x*x*x + b*x*x + c*x + d = 0`.
+//
+//	If `a` is 0, `SolveQuadraticComplex(b, c, d)` is returned instead.
+func SolveCubicComplex(a, b, c, d float64) []complex128 {
+	if a == 0 {
+		return SolveQuadraticComplex(b, c, d)
+	}
+	return solveCubicC(a, b, c, d)
+}
+
+//	Real roots are returned with an imaginary part of exactly 0.
+func solveCubicC(a, b, c, d float64) []complex128 {
+	b, c, d = b/a, c/a, d/a
+	q, r := (b*b-3*c)/9, (2*b*b*b-9*b*c+27*d)/54
+	off, q3 := b/3, q*q*q
+	if r*r < q3 {
+		theta, m := math.Acos(Clamp(r/math.Sqrt(q3), -1, 1)), -2*math.Sqrt(q)
+		return []complex128{
+			complex(m*math.Cos(theta/3)-off, 0),
+			complex(m*math.Cos((theta+2*math.Pi)/3)-off, 0),
+			complex(m*math.Cos((theta-2*math.Pi)/3)-off, 0),
+		}
+	}
+	ca := -math.Copysign(math.Cbrt(math.Abs(r)+math.Sqrt(r*r-q3)), r)
+	cb := 0.0
+	if ca != 0 {
+		cb = q / ca
+	}
+	re, im := -0.5*(ca+cb)-off, 0.5*math.Sqrt(3)*(ca-cb)
+	if Eq(ca, cb) {
+		im = 0
+	}
+	return []complex128{complex(ca+cb-off, 0), complex(re, im), complex(re, -im)}
+}
+
+//	Returns the distinct real roots of `a*x*x*x*x + b*x*x*x + c*x*x + d*x + e = 0`, in ascending order.
+//
+//	The quartic is depressed and factored into two quadratics via Ferrari's resolvent cubic;
+//	all roots are then polished with a Newton step against the original quartic.
+//	If `a` is 0, `SolveCubic(b, c, d, e)` is returned instead.
+func SolveQuartic(a, b, c, d, e float64) (roots []float64) {
+	if a == 0 {
+		return SolveCubic(b, c, d, e)
+	}
+	for _, r := range solveQuarticC(a, b, c, d, e) {
+		if imag(r) == 0 {
+			roots = append(roots, real(r))
+		}
+	}
+	return polishRoots(roots, a, b, c, d, e)
+}
+
+//	Returns all 4 (possibly complex or repeated) roots of `a*x*x*x*x + b*x*x*x + c*x*x + d*x + e = 0`.
+//
+//	If `a` is 0, `SolveCubicComplex(b, c, d, e)` is returned instead.
+func SolveQuarticComplex(a, b, c, d, e float64) []complex128 {
+	if a == 0 {
+		return SolveCubicComplex(b, c, d, e)
+	}
+	return solveQuarticC(a, b, c, d, e)
+}
+
+//	Real roots are returned with an imaginary part of exactly 0.
+func solveQuarticC(a, b, c, d, e float64) (roots []complex128) {
+	b, c, d, e = b/a, c/a, d/a, e/a
+	//	substitute x = scale*w, with roots w of order 1, so that the tolerances below are relative
+	scale := math.Max(math.Max(math.Abs(b), math.Sqrt(math.Abs(c))), math.Max(math.Cbrt(math.Abs(d)), math.Sqrt(math.Sqrt(math.Abs(e)))))
+	if scale == 0 {
+		return make([]complex128, 4)
+	}
+	b, c, d, e = b/scale, c/(scale*scale), d/(scale*scale*scale), e/(scale*scale*scale*scale)
+	//	depressed quartic: y^4 + p*y^2 + q*y + r = 0, with w = y - b/4
+	bb, off := b*b, b/4
+	p := c - 0.375*bb
+	q := d - 0.5*b*c + 0.125*bb*b
+	r := e - 0.25*b*d + 0.0625*bb*c - 3*bb*bb/256
+	if math.Abs(q) <= Epsilon*math.Max(1, math.Max(math.Abs(p), math.Abs(r))) {
+		//	biquadratic: z^2 + p*z + r = 0, with z = y^2
+		for _, z := range solveQuadraticC(complex(1, 0), complex(p, 0), complex(r, 0)) {
+			y := cmplx.Sqrt(z)
+			roots = append(roots, y, -y)
+		}
+	} else {
+		//	resolvent cubic m^3 + 2p*m^2 + (p^2 - 4r)*m - q^2 = 0 always has a positive real root when q != 0
+		m := 0.0
+		for _, z := range solveCubicC(1, 2*p, p*p-4*r, -q*q) {
+			if imag(z) == 0 && real(z) > m {
+				m = real(z)
+			}
+		}
+		s := math.Sqrt(m)
+		t, u := 0.5*(p+m-q/s), 0.5*(p+m+q/s)
+		roots = append(solveQuadraticC(1, complex(s, 0), complex(t, 0)), solveQuadraticC(1, complex(-s, 0), complex(u, 0))...)
+	}
+	for i := range roots {
+		roots[i] -= complex(off, 0)
+		//	a nearly real root is taken as real only if the quartic vanishes at its real part within rounding error
+		if x, im := real(roots[i]), imag(roots[i]); im != 0 && math.Abs(im) <= EpsilonEqFloatFactor*math.Max(1, cmplx.Abs(roots[i])) && polyVanishes(x, 1, b, c, d, e) {
+			roots[i] = complex(x, 0)
+		}
+		roots[i] *= complex(scale, 0)
+	}
+	return
+}
+
+//	Evaluates the polynomial with the specified `coeffs` (highest degree first) and its derivative at `x`.
+func polyEval(x float64, coeffs ...float64) (f, df float64) {
+	for _, k := range coeffs {
+		df = df*x + f
+		f = f*x + k
+	}
+	return
+}
+
+//	Newton-polishes, sorts and de-duplicates `roots` of the polynomial with the specified `coeffs`.
+func polishRoots(roots []float64, coeffs ...float64) []float64 {
+	for i, x := range roots {
+		f, df := polyEval(x, coeffs...)
+		for n := 0; n < 4 && f != 0 && df != 0; n++ {
+			nx := x - f/df
+			nf, ndf := polyEval(nx, coeffs...)
+			if math.Abs(nf) >= math.Abs(f) {
+				break
+			}
+			x, f, df = nx, nf, ndf
+		}
+		roots[i] = x
+	}
+	sort.Float64s(roots)
+	n := 0
+	for _, x := range roots {
+		//	merge only roots that are equal, or that the polynomial cannot tell apart between them
+		if n == 0 || (x != roots[n-1] && !polyVanishes((x+roots[n-1])/2, coeffs...)) {
+			roots[n] = x
+			n++
+		}
+	}
+	return roots[:n]
+}
+
+//	Returns whether the polynomial with the specified `coeffs` (highest degree first) evaluates to 0 at `x` to
+//	within the rounding error of evaluating it.
+func polyVanishes(x float64, coeffs ...float64) bool {
+	f, bound := 0.0, 0.0
+	for _, k := range coeffs {
+		f, bound = f*x+k, bound*math.Abs(x)+math.Abs(k)
+	}
+	return math.Abs(f) <= 8*float64(len(coeffs))*Epsilon*bound
+}
+
+//	Finds a root of `f` within the bracket [`a`, `b`] using Brent's method, to within the absolute tolerance `tol`.
+//
+//	`f(a)` and `f(b)` must differ in sign, otherwise `ok` is `false`.
+//	If `maxIter` is 0 or less, `RootsMaxIter` is used.
+func SolveBrent(f func(float64) float64, a, b, tol float64, maxIter int) (root float64, ok bool) {
+	if maxIter <= 0 {
+		maxIter = RootsMaxIter
+	}
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, true
+	} else if fb == 0 {
+		return b, true
+	} else if (fa > 0) == (fb > 0) {
+		return
+	}
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 0; i < maxIter; i++ {
+		if (fb > 0) == (fc > 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1, xm := 2*Epsilon*math.Abs(b)+0.5*tol, 0.5*(c-b)
+		if math.Abs(xm) <= tol1 || fb == 0 {
+			return b, true
+		}
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			//	attempt inverse quadratic interpolation (or secant if only 2 distinct points)
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p, q = 2*xm*s, 1-s
+			} else {
+				qa, r := fa/fc, fb/fc
+				p = s * (2*xm*qa*(qa-r) - (b-a)*(r-1))
+				q = (qa - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+			if 2*p < math.Min(3*xm*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = xm, xm
+			}
+		} else {
+			d, e = xm, xm
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, xm)
+		}
+		fb = f(b)
+	}
+	return b, false
+}
+
+//	Finds a root of `f` (with derivative `df`) within the bracket [`a`, `b`] using Newton-Raphson,
+//	falling back to bisection whenever a Newton step would leave the bracket or converge too slowly.
+//
+//	`f(a)` and `f(b)` must differ in sign, otherwise `ok` is `false`.
+//	If `maxIter` is 0 or less, `RootsMaxIter` is used.
+func SolveNewtonSafe(f, df func(float64) float64, a, b, tol float64, maxIter int) (root float64, ok bool) {
+	if maxIter <= 0 {
+		maxIter = RootsMaxIter
+	}
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, true
+	} else if fb == 0 {
+		return b, true
+	} else if (fa > 0) == (fb > 0) {
+		return
+	}
+	lo, hi := a, b
+	if fa > 0 {
+		lo, hi = b, a
+	}
+	root = 0.5 * (a + b)
+	dxOld := math.Abs(b - a)
+	dx := dxOld
+	fx, dfx := f(root), df(root)
+	for i := 0; i < maxIter; i++ {
+		if ((root-hi)*dfx-fx)*((root-lo)*dfx-fx) > 0 || math.Abs(2*fx) > math.Abs(dxOld*dfx) {
+			dxOld, dx = dx, 0.5*(hi-lo)
+			root = lo + dx
+		} else {
+			dxOld, dx = dx, fx/dfx
+			root -= dx
+		}
+		if math.Abs(dx) < tol {
+			return root, true
+		}
+		if fx, dfx = f(root), df(root); fx == 0 {
+			return root, true
+		} else if fx < 0 {
+			lo = root
+		} else {
+			hi = root
+		}
+	}
+	return root, false
+}
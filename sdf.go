@@ -0,0 +1,206 @@
+package unum
+
+import (
+	"math"
+)
+
+var (
+	//	`SDF.RayMarch` reports a hit once the distance to the surface falls below this.
+	SDFMarchEpsilon = 1e-4
+
+	//	`SDF.RayMarch` gives up after this many steps.
+	SDFMarchMaxSteps = 256
+
+	//	The offset at which `SDF.Normal` samples the field around a point.
+	SDFNormalEpsilon = 1e-4
+)
+
+//	Returns the signed distance from `pos` to a surface: negative inside, positive outside, 0 on the surface.
+//
+//	The `SDF...` primitives are centered at the origin; place them with `Transformed` and combine them with `SDFUnion`
+//	and friends, for example `SDF(func(p *Vec3) float64 { return SDFSphere(p, 1) }).Transformed(&mat)`.
+//	Some operators (smooth blends, subtraction, non-uniform scaling) yield only a bound on the true distance,
+//	which still suffices for `RayMarch`.
+type SDF func(pos *Vec3) float64
+
+//	Returns the unit-length surface normal (the normalized gradient) of `me` at `pos`, estimated from 4 samples
+//	`SDFNormalEpsilon` away in a tetrahedral pattern.
+func (me SDF) Normal(pos *Vec3) (normal *Vec3) {
+	normal = &Vec3{}
+	for _, k := range [4]Vec3{{1, -1, -1}, {-1, -1, 1}, {-1, 1, -1}, {1, 1, 1}} {
+		normal.Add(k.Scaled(me(k.ScaledAdded(SDFNormalEpsilon, pos))))
+	}
+	normal.NormalizeSafe()
+	return
+}
+
+//	Sphere-traces `ray` through `me` up to parameter `maxT`: steps along `ray` by the distance to the surface until
+//	within `SDFMarchEpsilon` of it (`ok`, at parameter `t`), beyond `maxT`, or `SDFMarchMaxSteps` elapse.
+//	A `ray` starting inside the surface hits at `t` 0.
+func (me SDF) RayMarch(ray *Ray, maxT float64) (t float64, ok bool) {
+	speed := ray.Dir.Magnitude()
+	if speed == 0 {
+		return
+	}
+	for i := 0; i < SDFMarchMaxSteps && t <= maxT; i++ {
+		d := me(ray.At(t))
+		if d < SDFMarchEpsilon {
+			return t, true
+		}
+		t += d / speed
+	}
+	return
+}
+
+//	Returns `me` repeated infinitely along each axis with a positive component in `period`: the cell of `me` around
+//	the origin is copied to every multiple of `period`. `me` should fit within half a `period` of the origin.
+func (me SDF) Repeated(period *Vec3) SDF {
+	return me.repeated(period, nil)
+}
+
+//	Returns `me` repeated as for `Repeated`, but only up to `limit` cells in either direction along each axis, for
+//	a total of `2 * limit + 1` copies per axis.
+func (me SDF) RepeatedLimited(period, limit *Vec3) SDF {
+	return me.repeated(period, limit)
+}
+
+//	Returns `me` transformed from its local space to the world space of the affine transformation `mat`, which
+//	must be invertible. Distances are scaled by the smallest scale factor of `mat`, so they remain exact for
+//	rotations, translations and uniform scaling, and a lower bound for non-uniform scaling.
+func (me SDF) Transformed(mat *Mat4) SDF {
+	var lin, inv, ata Mat3
+	lin.SetFromMat4(mat)
+	inv.SetFromInverseOf(&lin)
+	ata.SetFromMult3(lin.Transposed(), &lin)
+	vals, _ := ata.SymmetricEigen()
+	scale, trans := math.Sqrt(math.Max(vals.Z, 0)), Vec3{mat[12], mat[13], mat[14]}
+	return func(pos *Vec3) float64 {
+		var local, rel Vec3
+		rel.SetFromSub(pos, &trans)
+		local.MultMat3Vec3(&inv, &rel)
+		return me(&local) * scale
+	}
+}
+
+func (me SDF) repeated(period, limit *Vec3) SDF {
+	return func(pos *Vec3) float64 {
+		local := *pos
+		for i, c := range [3]*float64{&local.X, &local.Y, &local.Z} {
+			if p := period.at(i); p > 0 {
+				cell := math.Floor(*c/p + 0.5)
+				if limit != nil {
+					cell = Clamp(cell, -limit.at(i), limit.at(i))
+				}
+				*c -= p * cell
+			}
+		}
+		return me(&local)
+	}
+}
+
+//	Returns the signed distance from `pos` to the box with the specified `halfExtents`.
+func SDFBox(pos, halfExtents *Vec3) float64 {
+	qx, qy, qz := math.Abs(pos.X)-halfExtents.X, math.Abs(pos.Y)-halfExtents.Y, math.Abs(pos.Z)-halfExtents.Z
+	outside := Vec3{math.Max(qx, 0), math.Max(qy, 0), math.Max(qz, 0)}
+	return outside.Magnitude() + math.Min(math.Max(qx, math.Max(qy, qz)), 0)
+}
+
+//	Returns the signed distance from `pos` to the capsule of `radius` around the line segment from `a` to `b`.
+func SDFCapsule(pos, a, b *Vec3, radius float64) float64 {
+	var pa, ba Vec3
+	pa.SetFromSub(pos, a)
+	ba.SetFromSub(b, a)
+	h := 0.0
+	if l := ba.Length(); l > 0 {
+		h = Clamp01(pa.Dot(&ba) / l)
+	}
+	pa.Subtract(ba.Scaled(h))
+	return pa.Magnitude() - radius
+}
+
+//	Returns the signed distance from `pos` to the Y-aligned cylinder of the specified `radius` and `height`, with flat
+//	ends at `-height / 2` and `height / 2` (as per `NewMeshCylinder`).
+func SDFCylinder(pos *Vec3, radius, height float64) float64 {
+	dx, dy := math.Hypot(pos.X, pos.Z)-radius, math.Abs(pos.Y)-height/2
+	return math.Min(math.Max(dx, dy), 0) + math.Hypot(math.Max(dx, 0), math.Max(dy, 0))
+}
+
+//	Returns the signed distance from `pos` to the intersection of `a` and `b`.
+func SDFIntersection(a, b SDF) SDF {
+	return func(pos *Vec3) float64 {
+		return math.Max(a(pos), b(pos))
+	}
+}
+
+//	Returns the signed distance from `pos` to `plane`, negative behind it.
+func SDFPlane(pos *Vec3, plane *Plane) float64 {
+	return plane.SignedDistance(pos)
+}
+
+//	Returns the signed distance from `pos` to the box with the specified `halfExtents` whose edges and corners are
+//	rounded off with `radius`.
+func SDFRoundBox(pos, halfExtents *Vec3, radius float64) float64 {
+	return SDFBox(pos, halfExtents.Added(&Vec3{-radius, -radius, -radius})) - radius
+}
+
+//	Returns the signed distance from `pos` to the intersection of `a` and `b`, blended over a distance of about `k`.
+func SDFSmoothIntersection(a, b SDF, k float64) SDF {
+	return func(pos *Vec3) float64 {
+		return SmoothMax(a(pos), b(pos), k)
+	}
+}
+
+//	Returns the signed distance from `pos` to `a` with `b` carved out of it, blended over a distance of about `k`.
+func SDFSmoothSubtraction(a, b SDF, k float64) SDF {
+	return func(pos *Vec3) float64 {
+		return SmoothMax(a(pos), -b(pos), k)
+	}
+}
+
+//	Returns the signed distance from `pos` to the union of `a` and `b`, blended over a distance of about `k`.
+func SDFSmoothUnion(a, b SDF, k float64) SDF {
+	return func(pos *Vec3) float64 {
+		return SmoothMin(a(pos), b(pos), k)
+	}
+}
+
+//	Returns the signed distance from `pos` to the sphere of the specified `radius`.
+func SDFSphere(pos *Vec3, radius float64) float64 {
+	return pos.Magnitude() - radius
+}
+
+//	Returns the signed distance from `pos` to `a` with `b` carved out of it.
+func SDFSubtraction(a, b SDF) SDF {
+	return func(pos *Vec3) float64 {
+		return math.Max(a(pos), -b(pos))
+	}
+}
+
+//	Returns the signed distance from `pos` to the torus around the Y axis whose tube of `minorRadius` circles at
+//	`majorRadius` from the center (as per `NewMeshTorus`).
+func SDFTorus(pos *Vec3, majorRadius, minorRadius float64) float64 {
+	return math.Hypot(math.Hypot(pos.X, pos.Z)-majorRadius, pos.Y) - minorRadius
+}
+
+//	Returns the signed distance from `pos` to the union of `a` and `b`.
+func SDFUnion(a, b SDF) SDF {
+	return func(pos *Vec3) float64 {
+		return math.Min(a(pos), b(pos))
+	}
+}
+
+//	Returns the maximum of `a` and `b`, rounded off where they are within `k` of each other. See `SmoothMin`.
+func SmoothMax(a, b, k float64) float64 {
+	return -SmoothMin(-a, -b, k)
+}
+
+//	Returns the minimum of `a` and `b`, rounded off by a quadratic polynomial where they are within `k` of each
+//	other, so that it is continuously differentiable. The result is at most `k / 4` below `math.Min(a, b)`,
+//	which equals the result for `k <= 0`.
+func SmoothMin(a, b, k float64) float64 {
+	if k <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Max(k-math.Abs(a-b), 0) / k
+	return math.Min(a, b) - h*h*k/4
+}
@@ -0,0 +1,76 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Represents a capsule: all points within `Radius` of the segment from `A` to `B`.
+type Capsule struct {
+	A, B   Vec3
+	Radius float64
+}
+
+//	Returns the point on the surface of `me` that is farthest along `dir`.
+func (me *Capsule) Support(dir Vec3) Vec3 {
+	p := &me.A
+	if me.B.Dot(&dir) > me.A.Dot(&dir) {
+		p = &me.B
+	}
+	return supportRadius(p, &dir, me.Radius)
+}
+
+//	Represents the convex hull of a point set, without computing the hull itself.
+type ConvexPoints []Vec3
+
+//	Returns the point in `me` that is farthest along `dir`, or the zero `Vec3` if `me` is empty.
+func (me ConvexPoints) Support(dir Vec3) (p Vec3) {
+	best := math.Inf(-1)
+	for i := range me {
+		if d := me[i].Dot(&dir); d > best {
+			best, p = d, me[i]
+		}
+	}
+	return
+}
+
+//	Represents a sphere.
+type Sphere struct {
+	Center Vec3
+	Radius float64
+}
+
+//	Returns the point on the surface of `me` that is farthest along `dir`.
+func (me *Sphere) Support(dir Vec3) Vec3 {
+	return supportRadius(&me.Center, &dir, me.Radius)
+}
+
+func supportRadius(center, dir *Vec3, radius float64) (p Vec3) {
+	p = *center
+	if l := dir.Magnitude(); l > 0 {
+		p.SetFromAddScaled(center, dir, radius/l)
+	}
+	return
+}
+
+//	Represents a `ConvexShape` placed in the world by an affine transformation.
+type TransformedShape struct {
+	//	The untransformed shape.
+	Shape ConvexShape
+
+	//	Transforms `Shape` into world space.
+	Mat Mat4
+}
+
+//	Returns the point on `me` that is farthest along `dir`: the untransformed shape is queried along the
+//	direction transformed by the transpose of the linear part of `Mat`, and the result transformed by `Mat`.
+func (me *TransformedShape) Support(dir Vec3) Vec3 {
+	m := &me.Mat
+	local := Vec3{
+		m[0]*dir.X + m[1]*dir.Y + m[2]*dir.Z,
+		m[4]*dir.X + m[5]*dir.Y + m[6]*dir.Z,
+		m[8]*dir.X + m[9]*dir.Y + m[10]*dir.Z,
+	}
+	p := me.Shape.Support(local)
+	p.TransformCoord(m)
+	return p
+}
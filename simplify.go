@@ -0,0 +1,336 @@
+package unum
+
+import (
+	"container/heap"
+	"math"
+)
+
+//	How much more strongly `Mesh.Simplify` resists moving boundary edges than surface points,
+//	relative to the area of the triangles around them.
+var SimplifyBoundaryWeight = 1000.0
+
+//	Returns a simplified copy of `me` with at most `targetTriangles` triangles (if reachable), produced by greedily
+//	collapsing the edge of least quadric error (Garland-Heckbert) until the target is met or the next collapse would
+//	exceed `maxError`: the area-weighted mean squared distance of the merged vertex from the planes of the original
+//	triangles around the vertices it replaces.
+//
+//	Triangles are connected by equal positions, but vertices with distinct attributes at the same position (such as
+//	along UV seams or hard edges) remain distinct: seams and boundaries only ever collapse along themselves, keeping
+//	their vertices in place, and are never collapsed across. Where both ends of an edge lie in the interior, the merged
+//	vertex is placed where it minimizes the error. Surviving vertices keep their original `Normals`, `UVs` and
+//	`Tangents`, which callers may want to recompute (see `ComputeNormals`). Collapses that would flip a triangle
+//	or make the mesh non-manifold are skipped, and input that cannot be represented as a `HalfEdgeMesh` is returned
+//	as an unsimplified copy.
+func (me *Mesh) Simplify(targetTriangles int, maxError float64) (mesh *Mesh) {
+	s, ok := newMeshSimplifier(me)
+	if !ok {
+		return me.Clone()
+	}
+	for s.triangles > targetTriangles && s.queue.Len() > 0 {
+		c := heap.Pop(&s.queue).(simplifyCandidate)
+		if c.cost > maxError {
+			break
+		}
+		s.collapse(&c)
+	}
+	return s.result()
+}
+
+type meshSimplifier struct {
+	mesh      *Mesh
+	he        *HalfEdgeMesh
+	quadrics  []Mat4
+	weights   []float64
+	corners   []int
+	stamps    []int
+	stamp     int
+	triangles int
+	queue     simplifyQueue
+}
+
+type simplifyCandidate struct {
+	h      int
+	cost   float64
+	pos    Vec3
+	stamps [2]int
+}
+
+//	A min-heap of collapse candidates by cost.
+type simplifyQueue []simplifyCandidate
+
+func (me simplifyQueue) Len() int            { return len(me) }
+func (me simplifyQueue) Less(i, j int) bool  { return me[i].cost < me[j].cost }
+func (me simplifyQueue) Swap(i, j int)       { me[i], me[j] = me[j], me[i] }
+func (me *simplifyQueue) Push(x interface{}) { *me = append(*me, x.(simplifyCandidate)) }
+
+func (me *simplifyQueue) Pop() (x interface{}) {
+	x, *me = (*me)[len(*me)-1], (*me)[:len(*me)-1]
+	return
+}
+
+func newMeshSimplifier(mesh *Mesh) (me *meshSimplifier, ok bool) {
+	me = &meshSimplifier{mesh: mesh}
+	weld, welded := map[Vec3]uint32{}, make([]uint32, len(mesh.Positions))
+	var positions []Vec3
+	for v, p := range mesh.Positions {
+		i, exists := weld[p]
+		if !exists {
+			i = uint32(len(positions))
+			weld[p] = i
+			positions = append(positions, p)
+		}
+		welded[v] = i
+	}
+	num := len(mesh.Indices) - len(mesh.Indices)%3
+	indices := make([]uint32, num)
+	for c := range indices {
+		indices[c] = welded[mesh.Indices[c]]
+	}
+	if me.he, ok = NewHalfEdgeMesh(positions, indices); !ok {
+		return
+	}
+	//	for every face half-edge, the original vertex at its start corner
+	he := me.he
+	me.corners = make([]int, len(he.HalfEdges))
+	for f, h := range he.FaceEdge {
+		for k := 0; k < 3; k++ {
+			me.corners[h] = int(mesh.Indices[3*f+k])
+			h = he.HalfEdges[h].Next
+		}
+	}
+	me.quadrics, me.weights, me.stamps = make([]Mat4, len(positions)), make([]float64, len(positions)), make([]int, len(positions))
+	me.triangles = len(he.FaceEdge)
+	for f := range he.FaceEdge {
+		verts := he.FaceVertices(f)
+		n := he.FaceNormal(f)
+		e1, e2 := he.Positions[verts[1]].Sub(&he.Positions[verts[0]]), he.Positions[verts[2]].Sub(&he.Positions[verts[0]])
+		area := e1.Cross(e2).Magnitude() / 2
+		plane := Vec4{n.X, n.Y, n.Z, -n.Dot(&he.Positions[verts[0]])}
+		for _, v := range verts {
+			me.addQuadric(v, &plane, area)
+		}
+	}
+	for h := range he.HalfEdges {
+		if f := he.HalfEdges[h].Face; f >= 0 && he.HalfEdges[h^1].Face < 0 {
+			//	a plane through the boundary edge, perpendicular to its triangle
+			a, b := &he.Positions[he.From(h)], &he.Positions[he.HalfEdges[h].To]
+			edge := b.Sub(a)
+			n := edge.Cross(he.FaceNormal(f))
+			n.NormalizeSafe()
+			plane := Vec4{n.X, n.Y, n.Z, -n.Dot(a)}
+			w := SimplifyBoundaryWeight * edge.Length()
+			me.addQuadric(he.From(h), &plane, w)
+			me.addQuadric(he.HalfEdges[h].To, &plane, w)
+		}
+	}
+	for h := range he.HalfEdges {
+		me.push(h)
+	}
+	return me, true
+}
+
+//	Adds the quadric of `plane`, weighted by `weight`, to vertex `v`.
+func (me *meshSimplifier) addQuadric(v int, plane *Vec4, weight float64) {
+	p, q := [4]float64{plane.X, plane.Y, plane.Z, plane.W}, &me.quadrics[v]
+	for c := 0; c < 4; c++ {
+		for r := 0; r < 4; r++ {
+			q[c*4+r] += weight * p[r] * p[c]
+		}
+	}
+	me.weights[v] += weight
+}
+
+//	Returns the corner mapping for collapsing half-edge `h` (from `a` into `b`): for each original vertex at a corner
+//	of `a`, the original vertex at the corner of `b` on the same side of any seam. Returns `false` if the collapse
+//	would cross or break a seam or boundary.
+func (me *meshSimplifier) cornerMap(h int) (remap map[int]int, ok bool) {
+	he := me.he
+	a, t := he.From(h), h^1
+	if he.IsBoundaryVertex(a) && !he.IsBoundaryEdge(h) {
+		return nil, false
+	}
+	remap = map[int]int{}
+	for _, s := range []int{h, t} {
+		if he.HalfEdges[s].Face < 0 {
+			continue
+		}
+		//	the corners of a and b in the triangle left of s
+		atA, atB := me.corners[s], me.corners[he.HalfEdges[s].Next]
+		if s == t {
+			atA, atB = me.corners[he.HalfEdges[s].Next], me.corners[s]
+		}
+		if prev, exists := remap[atA]; exists && prev != atB {
+			return nil, false
+		}
+		remap[atA] = atB
+	}
+	//	every wedge of a must touch the edge, and distinct wedges must stay distinct
+	targets := map[int]bool{}
+	for _, to := range remap {
+		targets[to] = true
+	}
+	if len(targets) != len(remap) {
+		return nil, false
+	}
+	for _, o := range he.outgoing(a) {
+		if he.HalfEdges[o].Face >= 0 {
+			if _, exists := remap[me.corners[o]]; !exists {
+				return nil, false
+			}
+		}
+	}
+	return remap, true
+}
+
+//	Performs the collapse of candidate `c` if it is still current and valid.
+func (me *meshSimplifier) collapse(c *simplifyCandidate) {
+	he := me.he
+	a, b := he.From(c.h), he.HalfEdges[c.h].To
+	if b < 0 || c.stamps != [2]int{me.stamps[a], me.stamps[b]} || !he.CanCollapse(c.h) || me.flips(c.h, &c.pos) {
+		return
+	}
+	remap, ok := me.cornerMap(c.h)
+	if !ok {
+		return
+	}
+	newCorners := map[int]int{}
+	for _, o := range he.outgoing(a) {
+		if he.HalfEdges[o].Face >= 0 {
+			newCorners[o] = remap[me.corners[o]]
+		}
+	}
+	for _, s := range []int{c.h, c.h ^ 1} {
+		if he.HalfEdges[s].Face >= 0 {
+			//	`CollapseEdge` lets the next half-edge take over the place of the previous one's twin
+			n, po := he.HalfEdges[s].Next, he.HalfEdges[s].Prev^1
+			if newCorners[n] = me.corners[po]; he.From(po) == a {
+				newCorners[n] = remap[me.corners[po]]
+			}
+			me.triangles--
+		}
+	}
+	he.CollapseEdge(c.h, &c.pos)
+	for h, corner := range newCorners {
+		me.corners[h] = corner
+	}
+	me.quadrics[b].Add(&me.quadrics[a])
+	me.weights[b] += me.weights[a]
+	//	a fresh stamp invalidates all queued candidates involving b, or a (whose edges b inherits)
+	me.stamp++
+	me.stamps[b] = me.stamp
+	for _, o := range he.outgoing(b) {
+		me.push(o)
+		me.push(o ^ 1)
+	}
+}
+
+//	Returns whether moving the ends of half-edge `h` to `pos` would flip (or nearly flip) any remaining triangle.
+func (me *meshSimplifier) flips(h int, pos *Vec3) bool {
+	he := me.he
+	for _, v := range []int{he.From(h), he.HalfEdges[h].To} {
+		for _, o := range he.outgoing(v) {
+			f := he.HalfEdges[o].Face
+			if f < 0 || f == he.HalfEdges[h].Face || f == he.HalfEdges[h^1].Face {
+				continue
+			}
+			p1, p2 := &he.Positions[he.HalfEdges[o].To], &he.Positions[he.HalfEdges[he.HalfEdges[o].Next].To]
+			before := p1.Sub(&he.Positions[v]).Cross(p2.Sub(&he.Positions[v]))
+			after := p1.Sub(pos).Cross(p2.Sub(pos))
+			if after.Dot(before) <= 0.2*math.Sqrt(after.Length()*before.Length()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//	Pushes the candidate collapse of half-edge `h` from its start into its end, if permissible at all.
+func (me *meshSimplifier) push(h int) {
+	he := me.he
+	a, b := he.From(h), he.HalfEdges[h].To
+	if b < 0 || a < 0 {
+		return
+	}
+	if _, ok := me.cornerMap(h); !ok {
+		return
+	}
+	var q Mat4
+	q.CopyFrom(&me.quadrics[a])
+	q.Add(&me.quadrics[b])
+	pos := he.Positions[b]
+	if me.isFree(a) && me.isFree(b) {
+		//	solve for the position of least error, else pick the best of the ends and the midpoint
+		var m, inv Mat3
+		m.SetFromMat4(&q)
+		det, scale := m.Determinant(), (m[0]+m[4]+m[8])/3
+		if math.Abs(det) > 1e-9*scale*scale*scale && inv.SetFromInverseOf(&m) {
+			pos.MultMat3Vec3(&inv, &Vec3{-q[12], -q[13], -q[14]})
+		} else {
+			mid := he.Positions[a].Added(&he.Positions[b])
+			mid.Scale(0.5)
+			for _, p := range []*Vec3{&he.Positions[a], mid} {
+				if quadricError(&q, p) < quadricError(&q, &pos) {
+					pos = *p
+				}
+			}
+		}
+	}
+	w := me.weights[a] + me.weights[b]
+	if w <= 0 {
+		w = 1
+	}
+	heap.Push(&me.queue, simplifyCandidate{h, math.Max(0, quadricError(&q, &pos)) / w, pos, [2]int{me.stamps[a], me.stamps[b]}})
+}
+
+//	Returns whether vertex `v` may move: it lies neither on a boundary nor on a seam.
+func (me *meshSimplifier) isFree(v int) bool {
+	if me.he.IsBoundaryVertex(v) {
+		return false
+	}
+	out := me.he.outgoing(v)
+	for _, o := range out {
+		if me.corners[o] != me.corners[out[0]] {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns the simplified `Mesh`, keeping only the original vertices still referenced, moved to their new positions.
+func (me *meshSimplifier) result() (mesh *Mesh) {
+	src, he := me.mesh, me.he
+	mesh, remap := &Mesh{}, map[int]uint32{}
+	for _, h := range he.FaceEdge {
+		if h < 0 {
+			continue
+		}
+		for k := 0; k < 3; k++ {
+			v := me.corners[h]
+			i, ok := remap[v]
+			if !ok {
+				i = uint32(len(mesh.Positions))
+				remap[v] = i
+				mesh.Positions = append(mesh.Positions, he.Positions[he.From(h)])
+				if len(src.Normals) > 0 {
+					mesh.Normals = append(mesh.Normals, src.Normals[v])
+				}
+				if len(src.UVs) > 0 {
+					mesh.UVs = append(mesh.UVs, src.UVs[v])
+				}
+				if len(src.Tangents) > 0 {
+					mesh.Tangents = append(mesh.Tangents, src.Tangents[v])
+				}
+			}
+			mesh.Indices = append(mesh.Indices, i)
+			h = he.HalfEdges[h].Next
+		}
+	}
+	return
+}
+
+//	Returns the quadric error `pos^T * q * pos` of the homogeneous `pos`.
+func quadricError(q *Mat4, pos *Vec3) float64 {
+	var v Vec4
+	v.MultMat4Vec3(q, pos)
+	return v.Dot(&Vec4{pos.X, pos.Y, pos.Z, 1})
+}
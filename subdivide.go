@@ -0,0 +1,284 @@
+package unum
+
+import (
+	"math"
+)
+
+//	Maps edges of a `HalfEdgeMesh`, as pairs of vertex indices with the lower first, to their crease sharpness for
+//	subdivision: 0 (or absent) for smooth, `n` for an edge that stays sharp for `n` levels (fractions blend
+//	between smooth and sharp), and `math.Inf(1)` for a permanent crease. Boundary edges are always sharp.
+type EdgeSharpness map[[2]int]float64
+
+//	Returns the sharpness of the edge between vertices `a` and `b`.
+func (me EdgeSharpness) Get(a, b int) float64 {
+	if a > b {
+		a, b = b, a
+	}
+	return me[[2]int{a, b}]
+}
+
+//	Sets the sharpness of the edge between vertices `a` and `b`.
+func (me EdgeSharpness) Set(a, b int, sharpness float64) {
+	if a > b {
+		a, b = b, a
+	}
+	me[[2]int{a, b}] = sharpness
+}
+
+//	Returns the limit positions of all vertices of `me` under repeated `SubdivideCatmullClark`: where each vertex
+//	converges to after infinitely many levels. Exact for quad meshes (as are all meshes after 1 level) with smooth or
+//	permanently sharp edges; other faces contribute their centroid, and any positive `creases` sharpness counts as
+//	permanent. Entries for deleted vertices are left zero.
+func (me *HalfEdgeMesh) LimitPositionsCatmullClark(creases EdgeSharpness) []Vec3 {
+	return me.limitPositions(creases, func(v int, out []int) (pos Vec3) {
+		n := float64(len(out))
+		pos = *me.Positions[v].Scaled(n * n)
+		for _, o := range out {
+			pos.Add(me.Positions[me.HalfEdges[o].To].Scaled(4))
+			if f := me.HalfEdges[o].Face; me.faceDegree(f) == 4 {
+				pos.Add(&me.Positions[me.HalfEdges[me.HalfEdges[o].Next].To])
+			} else {
+				pos.Add(me.faceCentroid(f))
+			}
+		}
+		pos.Scale(1 / (n * (n + 5)))
+		return
+	})
+}
+
+//	Returns the limit positions of all vertices of `me` under repeated `SubdivideLoop`: where each vertex converges
+//	to after infinitely many levels. Exact for triangle meshes with smooth or permanently sharp edges; any positive
+//	`creases` sharpness counts as permanent. Entries for deleted vertices are left zero.
+func (me *HalfEdgeMesh) LimitPositionsLoop(creases EdgeSharpness) []Vec3 {
+	return me.limitPositions(creases, func(v int, out []int) (pos Vec3) {
+		n := float64(len(out))
+		gamma := 1 / (n + 3/(8*loopBeta(len(out))))
+		pos = *me.Positions[v].Scaled(1 - n*gamma)
+		for _, o := range out {
+			pos.Add(me.Positions[me.HalfEdges[o].To].Scaled(gamma))
+		}
+		return
+	})
+}
+
+//	Returns the next level of Catmull-Clark subdivision of `me`, which may have faces of any number of sides: every
+//	face becomes one quad per corner, joining the corner, the points on its 2 edges and a point inside the face.
+//	Vertex `v` of `me` remains vertex `v` (moved), followed by one vertex per edge, then one per face.
+//	Sharp and semi-sharp edges are treated as per DeRose et al.: `subCreases` has the sharpness of `creases`
+//	reduced by 1 for the subdivided edges, so that it may be passed along with `mesh` to the next level.
+func (me *HalfEdgeMesh) SubdivideCatmullClark(creases EdgeSharpness) (mesh *HalfEdgeMesh, subCreases EdgeSharpness) {
+	return me.subdivide(creases, false)
+}
+
+//	Returns the next level of Loop subdivision of the triangle mesh `me`: every triangle becomes 4, joining its
+//	corners and the points on its edges. Vertex `v` of `me` remains vertex `v` (moved), followed by one vertex per
+//	edge. Faces with more than 3 sides get a triangle per corner around a central polygon of their edge points,
+//	although Loop's weights are designed for triangles. Creases are handled as for `SubdivideCatmullClark`.
+func (me *HalfEdgeMesh) SubdivideLoop(creases EdgeSharpness) (mesh *HalfEdgeMesh, subCreases EdgeSharpness) {
+	return me.subdivide(creases, true)
+}
+
+func (me *HalfEdgeMesh) faceCentroid(f int) (c *Vec3) {
+	c = &Vec3{}
+	verts := me.FaceVertices(f)
+	for _, v := range verts {
+		c.Add(&me.Positions[v])
+	}
+	c.Scale(1 / float64(len(verts)))
+	return
+}
+
+//	Returns the limit positions of all vertices, using `smooth` for those with fewer than 2 sharp edges.
+func (me *HalfEdgeMesh) limitPositions(creases EdgeSharpness, smooth func(v int, out []int) Vec3) (limit []Vec3) {
+	limit = make([]Vec3, len(me.Positions))
+	for v := range me.Positions {
+		out := me.outgoing(v)
+		if len(out) == 0 {
+			continue
+		}
+		var sharp []int
+		for _, o := range out {
+			if me.sharpness(o, creases) > 0 {
+				sharp = append(sharp, me.HalfEdges[o].To)
+			}
+		}
+		switch len(sharp) {
+		case 0, 1:
+			limit[v] = smooth(v, out)
+		case 2:
+			//	the limit of the cubic B-spline the crease converges to
+			limit[v] = *me.Positions[v].Scaled(4)
+			limit[v].Add(&me.Positions[sharp[0]])
+			limit[v].Add(&me.Positions[sharp[1]])
+			limit[v].Scale(1.0 / 6)
+		default:
+			limit[v] = me.Positions[v]
+		}
+	}
+	return
+}
+
+//	Returns the sharpness of the edge of half-edge `h`, infinite if it lies on a boundary.
+func (me *HalfEdgeMesh) sharpness(h int, creases EdgeSharpness) float64 {
+	if me.IsBoundaryEdge(h) {
+		return math.Inf(1)
+	}
+	return creases.Get(me.From(h), me.HalfEdges[h].To)
+}
+
+func (me *HalfEdgeMesh) subdivide(creases EdgeSharpness, loop bool) (mesh *HalfEdgeMesh, subCreases EdgeSharpness) {
+	numVerts := len(me.Positions)
+	edgeVerts, faceVerts := make([]int, len(me.HalfEdges)/2), make([]int, len(me.FaceEdge))
+	next := numVerts
+	for e := range edgeVerts {
+		if edgeVerts[e] = -1; me.HalfEdges[2*e].To >= 0 {
+			edgeVerts[e], next = next, next+1
+		}
+	}
+	centroids := make([]Vec3, len(me.FaceEdge))
+	for f, h := range me.FaceEdge {
+		if faceVerts[f] = -1; h >= 0 {
+			centroids[f] = *me.faceCentroid(f)
+			if !loop {
+				faceVerts[f], next = next, next+1
+			}
+		}
+	}
+	positions := make([]Vec3, next)
+	for f, i := range faceVerts {
+		if i >= 0 {
+			positions[i] = centroids[f]
+		}
+	}
+	//	edge points
+	for e, i := range edgeVerts {
+		if i < 0 {
+			continue
+		}
+		h := 2 * e
+		a, b := &me.Positions[me.From(h)], &me.Positions[me.HalfEdges[h].To]
+		mid := a.Added(b)
+		mid.Scale(0.5)
+		if s := me.sharpness(h, creases); s >= 1 {
+			positions[i] = *mid
+		} else {
+			var smooth Vec3
+			if loop {
+				smooth = *mid.Scaled(0.75)
+				for _, side := range []int{h, h ^ 1} {
+					if f := me.HalfEdges[side].Face; me.faceDegree(f) == 3 {
+						smooth.Add(me.Positions[me.HalfEdges[me.HalfEdges[side].Next].To].Scaled(0.125))
+					} else {
+						smooth.Add(centroids[f].Scaled(0.125))
+					}
+				}
+			} else {
+				smooth.SetFromAdd(&centroids[me.HalfEdges[h].Face], &centroids[me.HalfEdges[h^1].Face])
+				smooth.Scale(0.25)
+				smooth.Add(mid.Scaled(0.5))
+			}
+			positions[i] = *Vec3_Lerp(&smooth, mid, s)
+		}
+	}
+	//	vertex points
+	for v := 0; v < numVerts; v++ {
+		out := me.outgoing(v)
+		if positions[v] = me.Positions[v]; len(out) == 0 {
+			continue
+		}
+		var sharp []int
+		sharpSum := 0.0
+		for _, o := range out {
+			if s := me.sharpness(o, creases); s > 0 {
+				sharp, sharpSum = append(sharp, me.HalfEdges[o].To), sharpSum+s
+			}
+		}
+		if len(sharp) < 2 {
+			positions[v] = me.subdivideSmoothVertex(v, out, centroids, loop)
+			continue
+		}
+		rule := me.Positions[v]
+		if len(sharp) == 2 {
+			//	the crease rule, identical for both schemes
+			rule.Scale(0.75)
+			rule.Add(me.Positions[sharp[0]].Scaled(0.125))
+			rule.Add(me.Positions[sharp[1]].Scaled(0.125))
+		}
+		if s := sharpSum / float64(len(sharp)); s >= 1 {
+			positions[v] = rule
+		} else {
+			//	finite sharpness means no boundary edges, so the smooth rule applies too
+			smooth := me.subdivideSmoothVertex(v, out, centroids, loop)
+			positions[v] = *Vec3_Lerp(&smooth, &rule, s)
+		}
+	}
+	//	topology
+	var faces [][]int
+	for f, h := range me.FaceEdge {
+		if h < 0 {
+			continue
+		}
+		hs := me.faceEdges(f)
+		num := len(hs)
+		es := make([]int, num)
+		for k, e := range hs {
+			es[k] = edgeVerts[e>>1]
+		}
+		for k, e := range hs {
+			corner, prev := me.From(e), es[(k+num-1)%num]
+			if loop {
+				faces = append(faces, []int{corner, es[k], prev})
+			} else {
+				faces = append(faces, []int{corner, es[k], faceVerts[f], prev})
+			}
+		}
+		if loop {
+			faces = append(faces, es)
+		}
+	}
+	mesh, _ = NewHalfEdgeMeshPolygons(positions, faces)
+	subCreases = EdgeSharpness{}
+	for key, s := range creases {
+		if s--; s > 0 {
+			a, b := key[0], key[1]
+			for _, o := range me.outgoing(a) {
+				if me.HalfEdges[o].To == b {
+					subCreases.Set(a, edgeVerts[o>>1], s)
+					subCreases.Set(edgeVerts[o>>1], b, s)
+				}
+			}
+		}
+	}
+	return
+}
+
+//	Returns the smooth-rule vertex point of interior vertex `v` with outgoing half-edges `out`.
+func (me *HalfEdgeMesh) subdivideSmoothVertex(v int, out []int, centroids []Vec3, loop bool) (smooth Vec3) {
+	n, p := float64(len(out)), &me.Positions[v]
+	if loop {
+		beta := loopBeta(len(out))
+		smooth = *p.Scaled(1 - n*beta)
+		for _, o := range out {
+			smooth.Add(me.Positions[me.HalfEdges[o].To].Scaled(beta))
+		}
+		return
+	}
+	//	(F + 2R + (n-3)v) / n, with F the mean of the face points and R of the edge midpoints
+	var faces, mids Vec3
+	for _, o := range out {
+		faces.Add(&centroids[me.HalfEdges[o].Face])
+		mids.Add(p.Added(&me.Positions[me.HalfEdges[o].To]))
+	}
+	smooth = *faces.Scaled(1 / n)
+	smooth.Add(mids.Scaled(1 / n))
+	smooth.Add(p.Scaled(n - 3))
+	smooth.Scale(1 / n)
+	return
+}
+
+//	Returns Loop's weight of each neighbor of a smooth vertex with `valence` neighbors.
+func loopBeta(valence int) float64 {
+	n := float64(valence)
+	c := 3.0/8 + math.Cos(2*math.Pi/n)/4
+	return (5.0/8 - c*c) / n
+}
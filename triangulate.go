@@ -0,0 +1,154 @@
+package unum
+
+import (
+	"math"
+	"sort"
+)
+
+type earNode struct {
+	i          int
+	prev, next *earNode
+}
+
+//	Triangulates the simple polygon `outer` minus the simple polygons `holes` (of any winding, and neither
+//	touching each other nor `outer`) by ear clipping, after bridging each hole into the outer boundary.
+//
+//	Returns the counter-clockwise triangles as an index buffer into the concatenation of `outer` and all `holes`
+//	(in order), 3 indices per triangle.
+func TriangulatePolygon(outer Polygon, holes ...Polygon) (indices []uint32) {
+	var pts Polygon
+	ring := func(poly Polygon, ccw bool) (first *earNode) {
+		offset := len(pts)
+		pts = append(pts, poly...)
+		if len(poly) < 3 {
+			return
+		}
+		var last *earNode
+		flip := poly.IsCCW() != ccw
+		for k := range poly {
+			i := offset + k
+			if flip {
+				i = offset + len(poly) - 1 - k
+			}
+			node := &earNode{i: i, prev: last}
+			if last == nil {
+				first = node
+			} else {
+				last.next = node
+			}
+			last = node
+		}
+		first.prev, last.next = last, first
+		return
+	}
+	head := ring(outer, true)
+	if head == nil {
+		return
+	}
+	//	bridge holes in order of decreasing maximum X, each at its rightmost vertex
+	var hs []*earNode
+	for _, hole := range holes {
+		if node := ring(hole, false); node != nil {
+			for n := node.next; n != node; n = n.next {
+				if pts[n.i].X > pts[node.i].X {
+					node = n
+				}
+			}
+			hs = append(hs, node)
+		}
+	}
+	sort.SliceStable(hs, func(a, b int) bool { return pts[hs[a].i].X > pts[hs[b].i].X })
+	for h, hole := range hs {
+		if bridge := earBridge(pts, head, hole, hs[h+1:]); bridge != nil {
+			//	splice in as: bridge, hole, ..., hole.prev, hole copy, bridge copy, bridge.next
+			next, last := bridge.next, hole.prev
+			h2, b2 := &earNode{i: hole.i}, &earNode{i: bridge.i}
+			bridge.next, hole.prev = hole, bridge
+			last.next, h2.prev = h2, last
+			h2.next, b2.prev = b2, h2
+			b2.next, next.prev = next, b2
+		}
+	}
+	//	clip ears until a single triangle remains
+	n := 1
+	for node := head.next; node != head; node = node.next {
+		n++
+	}
+	node := head
+	for stall := 0; n > 3; {
+		a, b, c := &pts[node.prev.i], &pts[node.i], &pts[node.next.i]
+		o := Orient2D(a, b, c)
+		if o > 0 && (stall >= 2*n || node.isEar(pts)) {
+			indices = append(indices, uint32(node.prev.i), uint32(node.i), uint32(node.next.i))
+		} else if o != 0 || stall < n {
+			//	no ear here: move on, giving up once a full pass found neither ears nor degenerate vertices
+			if node, stall = node.next, stall+1; stall >= 3*n {
+				return
+			}
+			continue
+		}
+		//	a clipped ear, or a degenerate vertex that is dropped without emitting a triangle
+		node.prev.next, node.next.prev = node.next, node.prev
+		node, stall, n = node.next, 0, n-1
+	}
+	if Orient2D(&pts[node.prev.i], &pts[node.i], &pts[node.next.i]) > 0 {
+		indices = append(indices, uint32(node.prev.i), uint32(node.i), uint32(node.next.i))
+	}
+	return
+}
+
+//	Returns whether the convex vertex `me` and its neighbors form an ear: a triangle containing no other vertex.
+func (me *earNode) isEar(pts Polygon) bool {
+	a, b, c := &pts[me.prev.i], &pts[me.i], &pts[me.next.i]
+	for n := me.next.next; n != me.prev; n = n.next {
+		p := &pts[n.i]
+		if *p != *a && *p != *b && *p != *c && Orient2D(a, b, p) >= 0 && Orient2D(b, c, p) >= 0 && Orient2D(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//	Returns whether the segment from `me` to `p` starts into the interior angle of the counter-clockwise boundary at `me`.
+func (me *earNode) locallyInside(pts Polygon, p *Vec2) bool {
+	a, prev, next := &pts[me.i], &pts[me.prev.i], &pts[me.next.i]
+	if Orient2D(prev, a, next) >= 0 {
+		return Orient2D(a, next, p) >= 0 && Orient2D(a, prev, p) <= 0
+	}
+	return Orient2D(a, next, p) >= 0 || Orient2D(a, prev, p) <= 0
+}
+
+//	Returns the vertex of the boundary `head` that is closest to the `hole` vertex while visible from it,
+//	with neither the boundary nor any of the `hole` and `pending` holes obstructing the bridge between them.
+func earBridge(pts Polygon, head, hole *earNode, pending []*earNode) (bridge *earNode) {
+	m := &pts[hole.i]
+	best := math.Inf(1)
+	blocked := func(ring *earNode, v *Vec2) bool {
+		for n := ring; ; {
+			a, b := &pts[n.i], &pts[n.next.i]
+			if *a != *m && *b != *m && *a != *v && *b != *v && SegmentsIntersect(m, v, a, b) {
+				return true
+			}
+			if n = n.next; n == ring {
+				return false
+			}
+		}
+	}
+	for n := head; ; {
+		v := &pts[n.i]
+		if d := m.Sub(v).Length(); d < best && n.locallyInside(pts, m) && !blocked(head, v) && !blocked(hole, v) {
+			ok := true
+			for _, other := range pending {
+				if ok = !blocked(other, v); !ok {
+					break
+				}
+			}
+			if ok {
+				bridge, best = n, d
+			}
+		}
+		if n = n.next; n == head {
+			return
+		}
+	}
+}
@@ -68,6 +68,12 @@ func (me *Vec2) Clear() {
 	me.X, me.Y = 0, 0
 }
 
+//	Returns the 2D cross product (the Z component of the 3D cross product) of `me` and `vec`:
+//	positive if `vec` is counter-clockwise from `me`, negative if clockwise, 0 if collinear.
+func (me *Vec2) Cross(vec *Vec2) float64 {
+	return me.X*vec.Y - me.Y*vec.X
+}
+
 func (me *Vec2) Distance(vec *Vec2) float64 {
 	return me.Sub(vec).Magnitude()
 }
@@ -173,6 +179,28 @@ func (me *Vec2) NormalizedScaledSafe(factor float64) *Vec2 {
 	return me.NormalizedSafe().Scaled(factor)
 }
 
+//	Returns a new `*Vec2` that is `me` rotated 90 degrees counter-clockwise.
+func (me *Vec2) Perp() *Vec2 {
+	return &Vec2{-me.Y, me.X}
+}
+
+//	Returns a new `*Vec2` that is `me` reflected off the surface with the specified unit-length `normal`.
+func (me *Vec2) Reflect(normal *Vec2) *Vec2 {
+	d := 2 * me.Dot(normal)
+	return &Vec2{me.X - d*normal.X, me.Y - d*normal.Y}
+}
+
+//	Rotates `me` `angleDeg` degrees counter-clockwise around the origin.
+func (me *Vec2) RotateDeg(angleDeg float64) {
+	me.RotateRad(DegToRad(angleDeg))
+}
+
+//	Rotates `me` `angleRad` radians counter-clockwise around the origin.
+func (me *Vec2) RotateRad(angleRad float64) {
+	sin, cos := math.Sincos(angleRad)
+	me.X, me.Y = me.X*cos-me.Y*sin, me.X*sin+me.Y*cos
+}
+
 //	Multiplies all components in `me` with `factor`.
 func (me *Vec2) Scale(factor float64) {
 	me.X, me.Y = me.X*factor, me.Y*factor
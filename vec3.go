@@ -104,6 +104,17 @@ func (me *Vec3) AngleRad(to *Vec3) float64 {
 	return math.Acos(Clamp(me.Normalized().Dot(to.Normalized()), -1, 1))
 }
 
+//	Returns the component of `me` along the specified `axis` (0 for `X`, 1 for `Y`, 2 for `Z`).
+func (me *Vec3) at(axis int) float64 {
+	switch axis {
+	case 0:
+		return me.X
+	case 1:
+		return me.Y
+	}
+	return me.Z
+}
+
 //	Clamps each component in `me` between the respective corresponding counter-part component in `min` and `max`.
 func (me *Vec3) Clamp(min, max *Vec3) {
 	if me.X < min.X {
@@ -164,6 +175,12 @@ func (me *Vec3) DistanceManhattan(vec *Vec3) float64 {
 	return math.Abs(vec.X-me.X) + math.Abs(vec.Y-me.Y) + math.Abs(vec.Z-me.Z)
 }
 
+//	Returns the squared distance of `me` from `vec`.
+func (me *Vec3) DistanceSq(vec *Vec3) float64 {
+	x, y, z := vec.X-me.X, vec.Y-me.Y, vec.Z-me.Z
+	return x*x + y*y + z*z
+}
+
 //	Returns a new `*Vec3` that represents `me` divided by `vec`.
 func (me *Vec3) Div(vec *Vec3) *Vec3 {
 	return &Vec3{me.X / vec.X, me.Y / vec.Y, me.Z / vec.Z}
@@ -229,6 +246,16 @@ func (me *Vec3) Mult3(x, y, z float64) *Vec3 {
 	return &Vec3{me.X * x, me.Y * y, me.Z * z}
 }
 
+//	Sets `me` to the result of multiplying the specified `*Mat3` with `me`.
+func (me *Vec3) MultMat3(mat *Mat3) {
+	me.MultMat3Vec3(mat, me)
+}
+
+//	Sets `me` to the result of multiplying the specified `*Mat3` with the specified `*Vec3`.
+func (me *Vec3) MultMat3Vec3(mat *Mat3, vec *Vec3) {
+	me.X, me.Y, me.Z = (mat[0]*vec.X)+(mat[3]*vec.Y)+(mat[6]*vec.Z), (mat[1]*vec.X)+(mat[4]*vec.Y)+(mat[7]*vec.Z), (mat[2]*vec.X)+(mat[5]*vec.Y)+(mat[8]*vec.Z)
+}
+
 //	Reverses the signs of all 3 vector components in `me`.
 func (me *Vec3) Negate() {
 	me.X, me.Y, me.Z = -me.X, -me.Y, -me.Z